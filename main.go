@@ -1,18 +1,28 @@
 package main
 
 import (
+	"bufio"
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 
 	"github.com/had-nu/lazy.go/pkg/config"
 	ghpkg "github.com/had-nu/lazy.go/pkg/github"
 	"github.com/had-nu/lazy.go/pkg/scaffold"
+	"github.com/had-nu/lazy.go/pkg/security"
 	"github.com/had-nu/lazy.go/pkg/tui"
 	"github.com/had-nu/lazy.go/pkg/wizard"
 )
@@ -38,69 +48,447 @@ security policies, CI pipelines, and GitHub repositories — tailored to
 your project's real purpose and risk profile.`,
 }
 
+var noDefaults bool
+
 func init() {
-	rootCmd.AddCommand(initCmd, validateCmd, versionCmd)
+	rootCmd.AddCommand(initCmd, validateCmd, configCmd, presetsCmd, schemaCmd, versionCmd)
+	rootCmd.PersistentFlags().BoolVar(&noDefaults, "no-defaults", false,
+		"Ignore the user defaults file ($XDG_CONFIG_HOME/lazygo/defaults.yml)")
 }
 
 // ---- init command ----------------------------------------------------------
 
-var fromFile string
+// fieldFlags are init's per-field flags. Each can also be set in the
+// environment as LAZYGO_<FLAG>, e.g. LAZYGO_TASK_RUNNER.
+var fieldFlags = []struct {
+	name, key, usage string
+	boolean          bool
+}{
+	{name: "name", key: "project.name", usage: "Project name"},
+	{name: "module", key: "project.module_path", usage: "Go module path"},
+	{name: "description", key: "project.description", usage: "Short project description"},
+	{name: "author", key: "project.author", usage: "Author or maintainer"},
+	{name: "type", key: "project.type", usage: "Project type (cli, api, microservice, library, security, worker)"},
+	{name: "license", key: "project.license", usage: "License (mit, apache-2.0, gpl-3.0, proprietary)"},
+	{name: "visibility", key: "project.visibility", usage: "Visibility (public, internal, private)"},
+	{name: "criticality", key: "project.criticality", usage: "Criticality (experimental, production, security-critical)"},
+	{name: "task-runner", key: "project.task_runner", usage: "Task runner (make, task, just)"},
+	{name: "docker-base", key: "docker.base", usage: "Docker runtime base image (distroless, scratch)"},
+	{name: "hook-manager", key: "hooks.manager", usage: "Git hook manager (pre-commit, lefthook)"},
+	{name: "binary", key: "cli.binary", usage: "Name of the built executable (default: the project name)"},
+	{name: "router", key: "api.router", usage: "HTTP router for API projects (stdlib, chi)"},
+	{name: "queue", key: "worker.queue", usage: "Queue backend for workers (none, redis, nats)"},
+	{name: "github", key: "github.enabled", usage: "Create a GitHub repository", boolean: true},
+	{name: "github-owner", key: "github.owner", usage: "GitHub user or organisation to create the repository under"},
+	{name: "github-repo", key: "github.repo", usage: "GitHub repository name (default: the project name)"},
+	{name: "github-topics", key: "github.topics", usage: "Comma-separated GitHub repository topics"},
+	{name: "default-branch", key: "github.default_branch", usage: "Default branch to push to and run CI on (default: main)"},
+	{name: "push", key: "github.push_on_init", usage: "Push the initial commit to GitHub", boolean: true},
+}
+
+// featureEnvPrefix prefixes environment variables that toggle features,
+// e.g. LAZYGO_FEATURE_DOCKER=true.
+const featureEnvPrefix = "LAZYGO_FEATURE_"
+
+// varEnvPrefix prefixes environment variables that set template variables,
+// e.g. LAZYGO_VAR_TEAM=payments. The variable name is lowercased.
+const varEnvPrefix = "LAZYGO_VAR_"
+
+var (
+	fromFile     string
+	presetName   string
+	featureFlags []string
+	varFlags     []string
+	resume       bool
+	discardSess  bool
+	accessible   bool
+	themeName    string
+)
+
+// stdin is shared by every prompt that reads answers from standard input,
+// so that none loses input another has buffered.
+var stdin = bufio.NewReader(os.Stdin)
 
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Start the interactive project wizard",
 	Long: `Start the lazy.go wizard to generate a new Go project.
 
-Use --from to replay a saved lazygo.yml configuration without the wizard.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		var cfg *config.ProjectConfig
+Use --from to replay a saved lazygo.yml configuration without the wizard,
+or --preset to answer everything but the name and module path from a preset.
+--from also reads lazygo.json and lazygo.toml, and "--from -" reads the
+configuration from stdin (YAML unless --format says otherwise).
 
-		if fromFile != "" {
-			// Headless mode: load config from YAML.
-			loaded, err := config.LoadFromYAML(fromFile)
+Every field can also be given as a flag (--name, --module, --type,
+--feature docker=true, --var team=payments, ...) or environment variable
+(LAZYGO_NAME, LAZYGO_FEATURE_DOCKER, LAZYGO_VAR_TEAM, ...). Precedence,
+highest first: flags, environment, --from file or --preset, defaults file.
+Once the name, module path and type are known the wizard is skipped, apart
+from asking for required template variables that are still missing; without
+a terminal on stdin, missing fields are an error.
+
+The wizard saves its progress after every step. If it is interrupted,
+--resume picks up where it stopped, and a plain "lazy.go init" offers to.
+Unfinished sessions expire after a week; --discard-session removes one.
+
+--accessible (or LAZYGO_ACCESSIBLE=1, or TERM=dumb) asks the same questions
+as plain numbered prompts, one per line, for screen readers, dumb terminals
+and CI logs. Answers can then be piped on stdin. NO_COLOR turns colour off
+in either mode.
+
+--theme picks the wizard's colours: dark, light, high-contrast, or the name
+of a YAML theme in $XDG_CONFIG_HOME/lazygo/themes. By default the dark or
+light theme is chosen to suit the terminal background.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		accessible = accessible || accessibleEnv()
+		sessionPath, _ := wizard.SessionPath()
+		if discardSess {
+			if sessionPath == "" {
+				return nil
+			}
+			if err := wizard.DiscardSession(sessionPath); err != nil {
+				return err
+			}
+			fmt.Println("✓ Discarded the unfinished wizard session")
+			return nil
+		}
+		if resume {
+			if sessionPath == "" {
+				return wizard.ErrNoSession
+			}
+			session, err := wizard.LoadSession(sessionPath)
 			if err != nil {
-				return fmt.Errorf("loading config from %s: %w", fromFile, err)
+				return err
 			}
-			cfg = loaded
-			fmt.Println("✓ Loaded configuration from", fromFile)
-		} else {
-			// Interactive TUI wizard.
-			m := tui.New()
-			p := tea.NewProgram(m, tea.WithAltScreen())
-			result, err := p.Run()
+			if !canPrompt() {
+				return fmt.Errorf("stdin is not a terminal, so the wizard cannot resume; use --accessible to answer on stdin")
+			}
+			presets, err := scaffold.Presets(presetsDir())
 			if err != nil {
-				return fmt.Errorf("TUI error: %w", err)
+				return err
 			}
+			return runWizard(tui.NewFromSession(session, presets), sessionPath)
+		}
 
-			final, ok := result.(tui.Model)
-			if !ok || !final.Done() {
-				fmt.Println("Wizard cancelled.")
-				return nil
+		overrides := initOverrides(cmd)
+
+		if fromFile != "" {
+			// Headless mode: load config from a file or stdin.
+			cfg, err := loadConfig(fromFile, overrides...)
+			if err != nil {
+				return fmt.Errorf("loading config from %s: %w", inputName(fromFile), err)
+			}
+			fmt.Println("✓ Loaded configuration from", inputName(fromFile))
+			finalize(cfg)
+			return runGeneration(cfg)
+		}
+
+		start, err := startingConfig(overrides)
+		if err != nil {
+			return err
+		}
+
+		missing := missingFields(start)
+		// Flags answered everything but required template variables: ask
+		// only for those.
+		varsOnly := len(missing) == 0 && len(overrides) > 0 &&
+			len(start.MissingVars()) > 0 && canPrompt()
+		if len(missing) == 0 && !varsOnly && (len(overrides) > 0 || !canPrompt()) {
+			// Headless mode: every answer came from flags, env or files.
+			diags := config.Check(start)
+			for _, d := range diags {
+				fmt.Fprintln(os.Stderr, d)
+			}
+			if diags.Fails(strictMode) {
+				return fmt.Errorf("configuration has problems")
 			}
+			finalize(start)
+			return runGeneration(start)
+		}
+		if !canPrompt() {
+			return fmt.Errorf("stdin is not a terminal, so the wizard cannot run; missing %s", strings.Join(missing, ", "))
+		}
 
-			// Print summary before generation.
-			fmt.Println(tui.RenderSummary(final.State()))
+		// A plain "lazy.go init" offers to resume an interrupted run.
+		if len(overrides) == 0 && presetName == "" && sessionPath != "" {
+			if session, err := wizard.LoadSession(sessionPath); err == nil && confirmResume(session) {
+				presets, err := scaffold.Presets(presetsDir())
+				if err != nil {
+					return err
+				}
+				return runWizard(tui.NewFromSession(session, presets), sessionPath)
+			}
+		}
 
-			cfg = wizard.BuildConfig(final.State())
+		// Interactive TUI wizard, pre-filled with everything known so far.
+		state := wizard.StateFromConfig(start)
+		var presets []scaffold.Preset
+		if varsOnly {
+			state.CurrentStep = wizard.StepVars
+		} else if presetName != "" {
+			state.Preset = presetName
+			state.CurrentStep = wizard.StepProjectName
+		} else if presets, err = scaffold.Presets(presetsDir()); err != nil {
+			return err
 		}
 
-		return runGeneration(cfg)
+		if varsOnly {
+			// A run that only asks for variables is not worth resuming.
+			sessionPath = ""
+		}
+		return runWizard(tui.NewFromState(state, presets), sessionPath)
 	},
 }
 
+// runWizard runs the TUI wizard m, saving its progress to sessionPath
+// unless that is empty, and generates or saves what it produces. The
+// session is removed once the answers have been used.
+func runWizard(m tui.Model, sessionPath string) error {
+	m = m.WithSuggestions(wizard.DetectSuggestions("."), githubLogin)
+	if !accessible {
+		theme, err := tui.LoadTheme(cmp.Or(themeName, os.Getenv("LAZYGO_THEME")), themesDir())
+		if err != nil {
+			return err
+		}
+		m = m.WithTheme(theme)
+	}
+	if sessionPath != "" {
+		m = m.WithSession(sessionPath)
+	}
+	var final tui.Model
+	if accessible {
+		var err error
+		final, err = tui.RunAccessible(m, stdin, os.Stdout)
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("input ended before the wizard finished; continue with: lazy.go init --resume --accessible")
+		}
+		if err != nil {
+			return fmt.Errorf("reading answers: %w", err)
+		}
+	} else {
+		p := tea.NewProgram(m, tea.WithAltScreen())
+		result, err := p.Run()
+		if err != nil {
+			return fmt.Errorf("TUI error: %w", err)
+		}
+		final, _ = result.(tui.Model)
+	}
+
+	var err error
+	if !final.Done() {
+		fmt.Println("Wizard cancelled.")
+		if sessionPath != "" {
+			if _, err := wizard.LoadSession(sessionPath); err == nil {
+				fmt.Println("Pick up where you left off with: lazy.go init --resume")
+			}
+		}
+		return nil
+	}
+
+	// Print summary before generation.
+	fmt.Println(final.Summary())
+
+	cfg := wizard.BuildConfig(final.State())
+	if final.SaveOnly() {
+		err = saveConfigOnly(cfg)
+	} else if config.Check(cfg).Fails(strictMode) {
+		return fmt.Errorf("configuration has problems (see summary above)")
+	} else {
+		err = runGeneration(cfg)
+	}
+	if err == nil && sessionPath != "" {
+		_ = wizard.DiscardSession(sessionPath)
+	}
+	return err
+}
+
+// confirmResume describes an unfinished session and asks on stdin whether
+// to resume it. Anything but "n" or "no" resumes.
+func confirmResume(s wizard.Session) bool {
+	name := s.State.ProjectName
+	if name == "" {
+		name = "an unnamed project"
+	}
+	fmt.Printf("An unfinished wizard session for %s was saved %s, at the %s step.\nResume it? [Y/n] ",
+		name, s.Saved.Format("Jan 2 15:04"), s.State.CurrentStep)
+	line, _ := stdin.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "n", "no":
+		return false
+	}
+	return true
+}
+
 func init() {
-	initCmd.Flags().StringVar(&fromFile, "from", "", "Load configuration from a lazygo.yml file")
+	initCmd.Flags().StringVar(&fromFile, "from", "", "Load configuration from a lazygo.yml, .json or .toml file, or - for stdin")
+	initCmd.Flags().StringVar(&formatName, "format", "", "Format of the --from file (yaml, json, toml); default from its extension")
+	initCmd.Flags().StringVar(&presetName, "preset", "", "Start from a named preset (see: lazy.go presets list)")
+	initCmd.Flags().BoolVar(&strictMode, "strict", false, "Treat configuration warnings as errors")
+	initCmd.Flags().BoolVar(&resume, "resume", false, "Resume the wizard session that was interrupted")
+	initCmd.Flags().BoolVar(&discardSess, "discard-session", false, "Remove the interrupted wizard session and exit")
+	initCmd.Flags().BoolVar(&accessible, "accessible", false, "Ask plain line-based questions instead of the full-screen wizard [$LAZYGO_ACCESSIBLE]")
+	initCmd.Flags().StringVar(&themeName, "theme", "", "Wizard colours: auto, dark, light, high-contrast or a user theme [$LAZYGO_THEME]")
+	initCmd.MarkFlagsMutuallyExclusive("from", "preset", "resume", "discard-session")
+
+	for _, f := range fieldFlags {
+		usage := fmt.Sprintf("%s [$%s]", f.usage, envName(f.name))
+		if f.boolean {
+			initCmd.Flags().Bool(f.name, false, usage)
+		} else {
+			initCmd.Flags().String(f.name, "", usage)
+		}
+	}
+	initCmd.Flags().StringArrayVar(&featureFlags, "feature", nil,
+		"Set a feature, e.g. docker=true or sast; repeatable [$"+featureEnvPrefix+"<NAME>]")
+	initCmd.Flags().StringArrayVar(&varFlags, "var", nil,
+		"Set a template variable, e.g. team=payments; repeatable [$"+varEnvPrefix+"<NAME>]")
+}
+
+// envName returns the environment variable backing a field flag.
+func envName(flag string) string {
+	return "LAZYGO_" + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// initOverrides collects field values from the environment and then from
+// flags, so that flags take precedence.
+func initOverrides(cmd *cobra.Command) []config.Override {
+	var overrides []config.Override
+	for _, f := range fieldFlags {
+		if v, ok := os.LookupEnv(envName(f.name)); ok {
+			overrides = append(overrides, config.Override{Key: f.key, Value: v, Source: envName(f.name)})
+		}
+	}
+	env := os.Environ()
+	sort.Strings(env)
+	for _, kv := range env {
+		name, v, _ := strings.Cut(kv, "=")
+		if feature, ok := strings.CutPrefix(name, featureEnvPrefix); ok {
+			overrides = append(overrides, config.Override{
+				Key: "features." + strings.ToLower(feature), Value: v, Source: name,
+			})
+		}
+		if variable, ok := strings.CutPrefix(name, varEnvPrefix); ok {
+			overrides = append(overrides, config.Override{
+				Key: "vars." + strings.ToLower(variable), Value: v, Source: name,
+			})
+		}
+	}
+
+	for _, f := range fieldFlags {
+		if flag := cmd.Flags().Lookup(f.name); flag.Changed {
+			overrides = append(overrides, config.Override{Key: f.key, Value: flag.Value.String(), Source: "--" + f.name})
+		}
+	}
+	for _, kv := range featureFlags {
+		feature, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			v = "true"
+		}
+		overrides = append(overrides, config.Override{
+			Key: "features." + strings.ToLower(feature), Value: v, Source: "--feature " + kv,
+		})
+	}
+	for _, kv := range varFlags {
+		name, v, _ := strings.Cut(kv, "=")
+		overrides = append(overrides, config.Override{
+			Key: "vars." + name, Value: v, Source: "--var " + kv,
+		})
+	}
+	return overrides
+}
+
+// finalize applies security enforcement and the license suggestion to a
+// headless run's checked configuration, noting each feature it turns on.
+func finalize(cfg *config.ProjectConfig) {
+	for _, e := range security.Enforcements(cfg) {
+		fmt.Fprintf(os.Stderr, "! features.%s is enforced to true for %s projects\n", e.Feature, cfg.Criticality)
+	}
+	wizard.Finalize(cfg)
+}
+
+// startingConfig layers the defaults file, the preset and overrides over
+// the wizard's defaults.
+func startingConfig(overrides []config.Override) (*config.ProjectConfig, error) {
+	cfg := wizard.DefaultConfig()
+	if path := defaultsFile(); path != "" {
+		if err := config.ApplyFile(cfg, path); err != nil {
+			return nil, fmt.Errorf("loading defaults from %s: %w", path, err)
+		}
+	}
+	if presetName != "" {
+		preset, err := scaffold.FindPreset(presetsDir(), presetName)
+		if err != nil {
+			return nil, err
+		}
+		if err := preset.Apply(cfg); err != nil {
+			return nil, fmt.Errorf("applying preset %s: %w", presetName, err)
+		}
+	}
+	if err := config.ApplyOverrides(cfg, overrides...); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// missingFields names the required fields cfg lacks, with how to set them.
+func missingFields(cfg *config.ProjectConfig) []string {
+	var missing []string
+	for _, f := range []struct {
+		flag  string
+		unset bool
+	}{
+		{"name", cfg.Name == ""},
+		{"module", cfg.ModulePath == ""},
+		{"type", cfg.Type == ""},
+	} {
+		if f.unset {
+			missing = append(missing, fmt.Sprintf("--%s (or %s)", f.flag, envName(f.flag)))
+		}
+	}
+	return missing
+}
+
+// githubLogin returns the authenticated GitHub user's login, or "" when it
+// cannot be found within a few seconds, e.g. offline or logged out.
+func githubLogin() string {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	login, err := ghpkg.Login(ctx)
+	if err != nil {
+		return ""
+	}
+	return login
+}
+
+// accessibleEnv reports whether the environment asks for the accessible
+// wizard: LAZYGO_ACCESSIBLE is true, or the terminal cannot move the cursor.
+func accessibleEnv() bool {
+	on, _ := strconv.ParseBool(os.Getenv("LAZYGO_ACCESSIBLE"))
+	return on || os.Getenv("TERM") == "dumb"
+}
+
+// canPrompt reports whether the wizard can ask questions: the accessible
+// wizard reads answers from any stdin, the full-screen one needs a terminal.
+func canPrompt() bool {
+	return accessible || stdinIsTerminal()
+}
+
+// stdinIsTerminal reports whether the wizard can read keys from stdin.
+func stdinIsTerminal() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
 }
 
 // ---- validate command ------------------------------------------------------
 
+var strictMode bool
+
 var validateCmd = &cobra.Command{
 	Use:   "validate <file>",
-	Short: "Validate a lazygo.yml configuration file",
+	Short: "Validate a lazygo.yml, .json or .toml configuration file (- for stdin)",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
-		cfg, err := config.LoadFromYAML(path)
+		cfg, err := loadConfig(path)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "✗ Invalid configuration: %v\n", err)
 			os.Exit(1)
@@ -111,6 +499,431 @@ var validateCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	validateCmd.Flags().BoolVar(&strictMode, "strict", false, "Treat warnings as errors")
+	validateCmd.Flags().StringVar(&formatName, "format", "", "Input format (yaml, json, toml); default from the file extension")
+}
+
+// formatName is the --format flag: the input format when it cannot or
+// should not be taken from the file extension.
+var formatName string
+
+// inputFormat returns the format to read path in: --format when set,
+// otherwise the one its extension names.
+func inputFormat(path string) (config.Format, error) {
+	if formatName != "" {
+		return config.ParseFormat(formatName)
+	}
+	return config.FormatOf(path), nil
+}
+
+// readInput reads path, or stdin when path is "-".
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("reading stdin: %w", err)
+		}
+		return data, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	return data, nil
+}
+
+// inputName labels path in messages.
+func inputName(path string) string {
+	if path == "-" {
+		return "<stdin>"
+	}
+	return path
+}
+
+// defaultsFile returns the defaults file to layer under every config, or ""
+// when --no-defaults is set.
+func defaultsFile() string {
+	if noDefaults {
+		return ""
+	}
+	path, err := config.DefaultsPath()
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// loadConfig loads a configuration file, or stdin when path is "-", with
+// its extends chain and the defaults file beneath it, and prints its
+// diagnostics to stderr in compiler style. Warnings only fail the load when
+// --strict is set.
+func loadConfig(path string, overrides ...config.Override) (*config.ProjectConfig, error) {
+	format, err := inputFormat(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readInput(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, diags, err := config.LoadData(inputName(path), data, format, defaultsFile(), overrides...)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range diags {
+		fmt.Fprintln(os.Stderr, d)
+	}
+	if diags.Fails(strictMode) {
+		if strictMode {
+			return nil, fmt.Errorf("%d problem(s), warnings are errors in strict mode", len(diags))
+		}
+		return nil, fmt.Errorf("%d error(s)", len(diags.Errors()))
+	}
+	return cfg, nil
+}
+
+// ---- config command --------------------------------------------------------
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect, convert and maintain lazygo.yml files",
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate <file>",
+	Short: "Upgrade a lazygo.yml file to the current schema version in place",
+	Long: `Upgrade a lazygo.yml file to the current schema version.
+
+The file is rewritten in place; comments and key order are preserved.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := args[0]
+		from, to, err := config.MigrateFile(path)
+		if err != nil {
+			return fmt.Errorf("migrating %s: %w", path, err)
+		}
+		if from == to {
+			fmt.Printf("✓ %s is already at schema version %d\n", path, to)
+			return nil
+		}
+		fmt.Printf("✓ Migrated %s from schema version %d to %d\n", path, from, to)
+		return nil
+	},
+}
+
+var showResolved bool
+
+var configShowCmd = &cobra.Command{
+	Use:   "show <file>",
+	Short: "Print a lazygo.yml file, optionally with inheritance resolved",
+	Long: `Print a lazygo.yml file.
+
+With --resolved, print the effective configuration instead: the files it
+extends and the user defaults file deep-merged underneath it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := args[0]
+		var (
+			data []byte
+			err  error
+		)
+		if showResolved {
+			data, err = config.Resolve(path, defaultsFile())
+		} else {
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	},
+}
+
+func init() {
+	configShowCmd.Flags().BoolVar(&showResolved, "resolved", false, "Merge extends and the defaults file into the output")
+	configConvertCmd.Flags().StringVar(&formatName, "format", "", "Input format (yaml, json, toml); default from the input extension")
+	configConvertCmd.Flags().StringVar(&convertTo, "to", "", "Output format (yaml, json, toml); default from the output extension")
+	configGetCmd.Flags().StringVar(&formatName, "format", "", "Input format (yaml, json, toml); default from the file extension")
+	configSetCmd.Flags().StringVar(&formatName, "format", "", "Input format (yaml, json, toml); default from the file extension")
+	configSetCmd.Flags().BoolVar(&setDryRun, "dry-run", false, "Report the effect of the change without writing the file")
+	configCmd.AddCommand(configMigrateCmd, configShowCmd, configConvertCmd, configGetCmd, configSetCmd)
+}
+
+var convertTo string
+
+var configConvertCmd = &cobra.Command{
+	Use:   "convert <in> [out]",
+	Short: "Convert a configuration file between YAML, JSON and TOML",
+	Long: `Convert a configuration file between YAML, JSON and TOML.
+
+Formats are taken from the file extensions unless --format (input) or --to
+(output) is given. Use - as the input to read stdin; omit the output, or
+use -, to write to stdout, in which case --to is required.
+
+The file is checked for unknown keys and mistyped values but need not be
+complete, so defaults files and presets convert too. extends is kept, and
+so are comments unless the output is JSON.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in, out := args[0], "-"
+		if len(args) == 2 {
+			out = args[1]
+		}
+
+		from, err := inputFormat(in)
+		if err != nil {
+			return err
+		}
+		var to config.Format
+		switch {
+		case convertTo != "":
+			if to, err = config.ParseFormat(convertTo); err != nil {
+				return err
+			}
+		case out == "-":
+			return fmt.Errorf("--to is required when writing to stdout")
+		default:
+			to = config.FormatOf(out)
+		}
+
+		data, err := readInput(in)
+		if err != nil {
+			return err
+		}
+		converted, err := config.Convert(inputName(in), data, from, to)
+		if err != nil {
+			return err
+		}
+		if out == "-" {
+			_, err = cmd.OutOrStdout().Write(converted)
+			return err
+		}
+		if err := os.WriteFile(out, converted, 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", out, err)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "✓ Converted %s (%s) to %s (%s)\n", inputName(in), from, out, to)
+		return nil
+	},
+}
+
+// configFile returns the file argument at index i, defaulting to lazygo.yml.
+func configFile(args []string, i int) string {
+	if len(args) > i {
+		return args[i]
+	}
+	return "lazygo.yml"
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key> [file]",
+	Short: "Print one setting of a lazygo.yml file",
+	Long: `Print one setting of a lazygo.yml file (default ./lazygo.yml).
+
+Keys are dotted paths such as project.criticality or features.docker; the
+short forms accepted by --set work too. The value is read with extends and
+the defaults file resolved. Lists are printed comma-separated.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, path := args[0], configFile(args, 1)
+		format, err := inputFormat(path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		value, ok, err := config.Get(path, data, format, defaultsFile(), key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%s is not set in %s", key, path)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), value)
+		return nil
+	},
+}
+
+var setDryRun bool
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value> [file]",
+	Short: "Change one setting of a lazygo.yml file, keeping its comments",
+	Long: `Change one setting of a lazygo.yml file (default ./lazygo.yml).
+
+The file is edited in place: comments, key order and formatting choices
+such as quoting survive, unlike files regenerated by init. Lists are given
+comma-separated.
+
+The edited configuration is validated, and not written if it has errors.
+Security enforcement is then applied as init would, noting any value it
+overrides, and the generated files that would change are listed.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, value, path := args[0], args[1], configFile(args, 2)
+		resolved, err := config.ResolveKey(key)
+		if err != nil {
+			return err
+		}
+		format, err := inputFormat(path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		// before is nil when the file has errors, which set may be fixing.
+		before, _, err := config.LoadData(path, data, format, defaultsFile())
+		if err != nil {
+			return err
+		}
+
+		edited, err := config.Set(data, format, resolved, value)
+		if err != nil {
+			return fmt.Errorf("setting %s: %w", resolved, err)
+		}
+		after, diags, err := config.LoadData(path, edited, format, defaultsFile())
+		if err != nil {
+			return err
+		}
+		for _, d := range diags {
+			fmt.Fprintln(os.Stderr, d)
+		}
+		if diags.Fails(false) {
+			return fmt.Errorf("%d error(s), %s left unchanged", len(diags.Errors()), path)
+		}
+
+		out := cmd.OutOrStdout()
+		set, _, _ := config.Value(after, resolved)
+		if before != nil {
+			wizard.Finalize(before)
+		}
+		wizard.Finalize(after)
+		if enforced, _, _ := config.Value(after, resolved); enforced != set {
+			fmt.Fprintf(out, "! %s is enforced to %s for %s projects\n", resolved, enforced, after.Criticality)
+		}
+
+		changes, err := scaffold.Diff(before, after)
+		if err != nil {
+			return err
+		}
+
+		verb := "Set"
+		if setDryRun {
+			verb = "Would set"
+		} else if err := os.WriteFile(path, edited, 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		fmt.Fprintf(out, "✓ %s %s = %s in %s\n", verb, resolved, set, path)
+		if len(changes) == 0 {
+			fmt.Fprintln(out, "  No generated files change.")
+			return nil
+		}
+		fmt.Fprintln(out, "  Generated files that would change:")
+		for _, c := range changes {
+			fmt.Fprintf(out, "    %s\n", c)
+		}
+		return nil
+	},
+}
+
+// ---- presets command -------------------------------------------------------
+
+var presetsCmd = &cobra.Command{
+	Use:   "presets",
+	Short: "List and inspect project presets",
+	Long: `List and inspect project presets.
+
+Built-in presets ship with lazy.go. Add your own as *.yml files in
+$XDG_CONFIG_HOME/lazygo/presets; a user preset replaces a built-in one with
+the same name. A preset is a lazygo.yml without name or module_path, and
+its first comment line is shown as its summary.`,
+}
+
+var presetsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available presets",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presets, err := scaffold.Presets(presetsDir())
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+		for _, p := range presets {
+			fmt.Fprintf(w, "%s\t%s", p.Name, p.Summary)
+			if !p.Builtin() {
+				fmt.Fprintf(w, "\t(%s)", p.Source())
+			}
+			fmt.Fprintln(w)
+		}
+		return w.Flush()
+	},
+}
+
+var presetsShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Print a preset",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := scaffold.FindPreset(presetsDir(), args[0])
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(p.Data)
+		return err
+	},
+}
+
+func init() {
+	presetsCmd.AddCommand(presetsListCmd, presetsShowCmd)
+}
+
+// presetsDir returns the user presets directory, or "" when it cannot be located.
+func presetsDir() string {
+	dir, err := scaffold.PresetsDir()
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// themesDir returns the user themes directory, or "" when it cannot be located.
+func themesDir() string {
+	dir, err := tui.ThemesDir()
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// ---- schema command --------------------------------------------------------
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema for lazygo.yml",
+	Long: `Print the JSON Schema describing lazygo.yml.
+
+Editors using yaml-language-server (VS Code, JetBrains) pick it up from the
+header of exported lazygo.yml files. To use a local copy instead:
+
+  lazy.go schema > lazygo.schema.json
+  # yaml-language-server: $schema=./lazygo.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := config.SchemaJSON()
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	},
+}
+
 // ---- version command -------------------------------------------------------
 
 var versionCmd = &cobra.Command{
@@ -129,8 +942,14 @@ func runGeneration(cfg *config.ProjectConfig) error {
 
 	fmt.Printf("\n⟳ Generating %s in ./%s ...\n\n", cfg.Name, cfg.Name)
 
+	// Pin base images by digest, the one step that needs the network.
+	digests, err := scaffold.ResolveDigests(context.Background(), cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warn: base images left unpinned: %v\n", err)
+	}
+
 	// Scaffold the project.
-	gen := scaffold.New(cfg, outDir)
+	gen := scaffold.New(cfg, outDir).WithDigests(digests)
 	if err := gen.Generate(); err != nil {
 		return fmt.Errorf("generation failed: %w", err)
 	}
@@ -162,12 +981,35 @@ func runGeneration(cfg *config.ProjectConfig) error {
 		if err := ghpkg.CreateRepository(ctx, opts); err != nil {
 			fmt.Fprintf(os.Stderr, "⚠ GitHub integration failed: %v\n", err)
 			fmt.Fprintln(os.Stderr, "  The project was generated locally. You can push manually.")
-		} else {
+		} else if opts.PushOnInit {
 			fmt.Println("✓ Repository created and pushed to GitHub.")
+		} else {
+			fmt.Println("✓ Repository created on GitHub. Push the project when ready.")
 		}
 	}
 
-	fmt.Printf("🎉 Done! Start building:\n\n  cd %s && make build\n\n", cfg.Name)
+	build := string(cfg.Runner()) + " build"
+	if len(scaffold.Dependencies(cfg)) > 0 {
+		build = "go mod tidy && " + build // go.mod requires modules but ships no go.sum
+	}
+	fmt.Printf("🎉 Done! Start building:\n\n  cd %s && %s\n\n", cfg.Name, build)
+	return nil
+}
+
+// saveConfigOnly writes cfg to lazygo.yml in the current directory without
+// generating the project, or to <name>.lazygo.yml when lazygo.yml exists.
+func saveConfigOnly(cfg *config.ProjectConfig) error {
+	path := "lazygo.yml"
+	if _, err := os.Stat(path); err == nil {
+		path = cfg.Name + ".lazygo.yml"
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("both lazygo.yml and %s exist; move one aside and run the wizard again", path)
+		}
+	}
+	if err := config.ExportToYAML(cfg, path); err != nil {
+		return fmt.Errorf("exporting config: %w", err)
+	}
+	fmt.Printf("✓ Configuration saved to %s\n\nGenerate the project later with:\n\n  lazy.go init --from %s\n\n", path, path)
 	return nil
 }
 
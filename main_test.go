@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// run executes the lazy.go command line with args, with no user defaults,
+// and returns what it printed.
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	rootCmd.SetArgs(args)
+	t.Cleanup(func() { rootCmd.SetArgs(nil) })
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func TestConfigSet_FixesInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazygo.yml")
+	invalid := `schema_version: 1
+project:
+  name: svc
+  module_path: github.com/acme/svc
+  type: wat
+  visibility: public
+  criticality: experimental
+`
+	if err := os.WriteFile(path, []byte(invalid), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := run(t, "config", "set", "project.type", "api", path)
+	if err != nil {
+		t.Fatalf("config set on an invalid file: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "type: api") {
+		t.Errorf("file not fixed:\n%s", data)
+	}
+	if !strings.Contains(out, "+ go.mod") {
+		t.Errorf("generated files not listed as added:\n%s", out)
+	}
+}
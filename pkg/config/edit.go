@@ -0,0 +1,186 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Get returns the value of key in a configuration document, with its
+// extends chain and the defaults file (when non-empty and present) merged
+// underneath. Lists are returned comma-separated, as Set accepts them. ok
+// is false when no file sets the key. name labels errors and anchors
+// relative extends paths, as in LoadData.
+func Get(name string, data []byte, format Format, defaults, key string) (value string, ok bool, err error) {
+	path, err := ResolveKey(key)
+	if err != nil {
+		return "", false, err
+	}
+	src, err := resolveData(name, data, format, defaults)
+	if err != nil {
+		return "", false, err
+	}
+	n := lookup(src.doc, path)
+	if n == nil {
+		return "", false, nil
+	}
+	return nodeString(n), true, nil
+}
+
+// Value returns the value of key in cfg, formatted like Get. Keys left at
+// their zero value read as such; an unset template variable is not ok.
+func Value(cfg *ProjectConfig, key string) (value string, ok bool, err error) {
+	path, err := ResolveKey(key)
+	if err != nil {
+		return "", false, err
+	}
+	f := toYAMLFile(cfg)
+	doc := new(yaml.Node)
+	if err := doc.Encode(&f); err != nil {
+		return "", false, fmt.Errorf("marshalling config: %w", err)
+	}
+	n := lookup(doc, path)
+	if n == nil {
+		if _, isVar := strings.CutPrefix(path, varsPrefix); isVar {
+			return "", false, nil
+		}
+		// Omitted because empty, e.g. docker.base.
+		return "", true, nil
+	}
+	return nodeString(n), true, nil
+}
+
+// Set returns data, a configuration document in format, with key set to
+// value. The document is edited in place, so comments, key order and the
+// quoting of the replaced value survive; a missing key is appended to its
+// section, creating the section if needed. The key must exist in the
+// schema and value must suit its type. The result is not validated as a
+// whole; load it to check it.
+func Set(data []byte, format Format, key, value string) ([]byte, error) {
+	path, fe := resolveKey(key)
+	if fe != nil {
+		return nil, fe
+	}
+	t, ok := leafTypes()[path]
+	if !ok {
+		t = reflect.TypeOf("") // vars.<name>
+	}
+	val, err := scalarFor(t, value)
+	if err != nil {
+		return nil, &FieldError{Field: path, Message: err.Error()}
+	}
+
+	doc, err := parseDocument(data, format)
+	if err != nil {
+		return nil, err
+	}
+	indent := indentOf(doc)
+	if err := setNode(doc, path, val); err != nil {
+		return nil, err
+	}
+	if format == FormatYAML {
+		return encodeIndented(doc, indent)
+	}
+	return encodeDocument(doc, format)
+}
+
+// indentOf returns the indentation a YAML document uses for its sections,
+// so an edit does not reformat the whole file; 4 when it has none.
+func indentOf(doc *yaml.Node) int {
+	root, err := rootMapping(doc)
+	if err != nil {
+		return 4
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, val := root.Content[i], root.Content[i+1]
+		if val.Kind == yaml.MappingNode && val.Style&yaml.FlowStyle == 0 && len(val.Content) > 0 {
+			if indent := val.Content[0].Column - key.Column; indent > 0 {
+				return indent
+			}
+		}
+	}
+	return 4
+}
+
+// setNode stores val under the dotted path in doc.
+func setNode(doc *yaml.Node, path string, val *yaml.Node) error {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	parent, err := rootMapping(doc)
+	if err != nil {
+		return err
+	}
+	parts := strings.Split(path, ".")
+	for depth, part := range parts[:len(parts)-1] {
+		i := mappingIndex(parent, part)
+		if i < 0 {
+			m := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, m)
+			parent = m
+			continue
+		}
+		if next := parent.Content[i+1]; next.Kind == yaml.MappingNode {
+			parent = next
+			continue
+		}
+		return fmt.Errorf("line %d: %s is not a mapping", parent.Content[i].Line, strings.Join(parts[:depth+1], "."))
+	}
+
+	leaf := parts[len(parts)-1]
+	i := mappingIndex(parent, leaf)
+	if i < 0 {
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: leaf}, val)
+		return nil
+	}
+	old := parent.Content[i+1]
+	val.HeadComment, val.LineComment, val.FootComment = old.HeadComment, old.LineComment, old.FootComment
+	switch {
+	case old.Kind == yaml.SequenceNode && val.Kind == yaml.SequenceNode:
+		val.Style = old.Style // keep [a, b] or block form
+	case old.Kind == yaml.ScalarNode && val.ShortTag() == "!!str":
+		val.Style = old.Style & (yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle)
+	}
+	parent.Content[i+1] = val
+	return nil
+}
+
+// lookup returns the value node at a dotted path in doc, or nil.
+func lookup(doc *yaml.Node, path string) *yaml.Node {
+	n, err := rootMapping(doc)
+	if err != nil {
+		return nil
+	}
+	for _, part := range strings.Split(path, ".") {
+		if n.Kind != yaml.MappingNode {
+			return nil
+		}
+		i := mappingIndex(n, part)
+		if i < 0 {
+			return nil
+		}
+		n = n.Content[i+1]
+	}
+	return n
+}
+
+// nodeString formats a value node: scalars as written, sequences as a
+// comma-separated list.
+func nodeString(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.SequenceNode:
+		items := make([]string, len(n.Content))
+		for i, c := range n.Content {
+			items[i] = nodeString(c)
+		}
+		return strings.Join(items, ",")
+	case yaml.AliasNode:
+		return nodeString(n.Alias)
+	}
+	if n.ShortTag() == "!!null" {
+		return ""
+	}
+	return n.Value
+}
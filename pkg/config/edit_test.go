@@ -0,0 +1,94 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+const commentedConfig = `# Payments API: handles card data.
+schema_version: 1
+project:
+  name: "pay"
+  module_path: github.com/x/pay
+  type: api
+  # Prototype until the PCI audit is done.
+  criticality: experimental # revisit in Q3
+features:
+  docker: false # deployed on Lambda
+`
+
+func TestSet_PreservesCommentsAndOrder(t *testing.T) {
+	out, err := config.Set([]byte(commentedConfig), config.FormatYAML, "criticality", "production")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Replace(commentedConfig, "criticality: experimental", "criticality: production", 1)
+	if string(out) != want {
+		t.Errorf("Set rewrote more than the value:\n%s\nwant:\n%s", out, want)
+	}
+
+	out, err = config.Set(out, config.FormatYAML, "project.name", "payments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `name: "payments"`) {
+		t.Errorf("quoting of the replaced value lost:\n%s", out)
+	}
+}
+
+func TestSet_AddsMissingKeys(t *testing.T) {
+	out, err := config.Set([]byte(commentedConfig), config.FormatYAML, "features.tags", "a, b")
+	if err == nil {
+		t.Fatalf("Set accepted an unknown key:\n%s", out)
+	}
+
+	out, err = config.Set([]byte(commentedConfig), config.FormatYAML, "vars.team", "payments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(out), "vars:\n  team: payments\n") {
+		t.Errorf("vars section not appended:\n%s", out)
+	}
+	value, ok, err := config.Get("lazygo.yml", out, config.FormatYAML, "", "vars.team")
+	if err != nil || !ok || value != "payments" {
+		t.Errorf("Get(vars.team) = %q, %v, %v", value, ok, err)
+	}
+}
+
+func TestSet_RejectsWrongType(t *testing.T) {
+	_, err := config.Set([]byte(commentedConfig), config.FormatYAML, "features.docker", "maybe")
+	if err == nil || !strings.Contains(err.Error(), "want true or false") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestGet_ResolvesExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeNamed(t, dir, "base.toml", "[project]\ncriticality = \"production\"\n")
+	data := "extends: base.toml\nproject:\n    name: svc\n"
+	path := writeNamed(t, dir, "lazygo.yml", data)
+
+	value, ok, err := config.Get(path, []byte(data), config.FormatYAML, "", "criticality")
+	if err != nil || !ok || value != "production" {
+		t.Errorf("Get(criticality) = %q, %v, %v", value, ok, err)
+	}
+	if _, ok, _ := config.Get(path, []byte(data), config.FormatYAML, "", "license"); ok {
+		t.Error("Get reported an unset key as set")
+	}
+}
+
+func TestValue(t *testing.T) {
+	cfg := validCfg()
+	cfg.Features.SAST = true
+	for key, want := range map[string]string{
+		"features.sast": "true",
+		"type":          string(cfg.Type),
+		"docker.base":   "",
+	} {
+		if got, ok, err := config.Value(cfg, key); err != nil || !ok || got != want {
+			t.Errorf("Value(%s) = %q, %v, %v; want %q", key, got, ok, err, want)
+		}
+	}
+}
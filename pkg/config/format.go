@@ -0,0 +1,315 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a serialisation lazygo configuration can be stored in. Every
+// format shares the lazygo.yml schema; JSON and TOML documents are parsed
+// into the same node tree as YAML, so strictness and diagnostics match.
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+	FormatTOML Format = "toml"
+)
+
+// AllFormats returns every supported configuration format.
+func AllFormats() []Format {
+	return []Format{FormatYAML, FormatJSON, FormatTOML}
+}
+
+// ParseFormat parses a format name; "yml" is accepted for YAML.
+func ParseFormat(s string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), ".")))
+	if f == "yml" {
+		return FormatYAML, nil
+	}
+	for _, valid := range AllFormats() {
+		if f == valid {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q (want yaml, json or toml)", s)
+}
+
+// FormatOf returns the format implied by path's extension, defaulting to
+// YAML for unknown or missing extensions.
+func FormatOf(path string) Format {
+	if ext := filepath.Ext(path); ext != "" {
+		if f, err := ParseFormat(ext); err == nil {
+			return f
+		}
+	}
+	return FormatYAML
+}
+
+// name is the format as written in messages, e.g. "TOML".
+func (f Format) name() string {
+	return strings.ToUpper(string(f))
+}
+
+// parseDocument parses data in the given format into a yaml.v3 document.
+func parseDocument(data []byte, format Format) (*yaml.Node, error) {
+	doc := new(yaml.Node)
+	var err error
+	switch format {
+	case FormatJSON:
+		// JSON is a subset of YAML, but not the other way round: check the
+		// syntax first so a YAML file named .json is not silently accepted.
+		if err = jsonSyntax(data); err == nil {
+			if err = yaml.Unmarshal(data, doc); err == nil {
+				blockStyle(doc)
+			}
+		}
+	case FormatTOML:
+		doc, err = parseTOML(data)
+	default:
+		err = yaml.Unmarshal(data, doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", format.name(), err)
+	}
+	return doc, nil
+}
+
+// blockStyle clears the flow and quoting styles JSON syntax gives every
+// node, so the document encodes as YAML a person would write. yaml.v3
+// still quotes strings that would otherwise read as another type.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// jsonSyntax reports a JSON syntax error with its line and column.
+func jsonSyntax(data []byte) error {
+	var v any
+	err := json.Unmarshal(data, &v)
+	var se *json.SyntaxError
+	if !errors.As(err, &se) {
+		return err
+	}
+	line, col := 1, 1
+	for _, c := range data[:min(int(se.Offset), len(data))] {
+		if c == '\n' {
+			line, col = line+1, 1
+		} else {
+			col++
+		}
+	}
+	return fmt.Errorf("line %d, column %d: %w", line, col, err)
+}
+
+// encodeDocument serialises a document in the given format. Comments are
+// kept in YAML and TOML; JSON has none.
+func encodeDocument(doc *yaml.Node, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(jsonNode{doc}); err != nil {
+			return nil, fmt.Errorf("encoding JSON: %w", err)
+		}
+		return buf.Bytes(), nil
+	case FormatTOML:
+		out, err := encodeTOML(doc)
+		if err != nil {
+			return nil, fmt.Errorf("encoding TOML: %w", err)
+		}
+		return out, nil
+	default:
+		return encodeNode(doc)
+	}
+}
+
+// jsonNode marshals a yaml.Node with encoding/json, keeping mapping key
+// order, which a Go map would lose.
+type jsonNode struct{ *yaml.Node }
+
+func (n jsonNode) MarshalJSON() ([]byte, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return []byte("null"), nil
+		}
+		return marshalJSON(jsonNode{n.Content[0]})
+	case yaml.AliasNode:
+		return marshalJSON(jsonNode{n.Alias})
+	case yaml.SequenceNode:
+		items := make([]jsonNode, len(n.Content))
+		for i, item := range n.Content {
+			items[i] = jsonNode{item}
+		}
+		return marshalJSON(items)
+	case yaml.MappingNode:
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			k, err := marshalJSON(n.Content[i].Value)
+			if err != nil {
+				return nil, err
+			}
+			v, err := marshalJSON(jsonNode{n.Content[i+1]})
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(k)
+			buf.WriteByte(':')
+			buf.Write(v)
+		}
+		buf.WriteByte('}')
+		return buf.Bytes(), nil
+	}
+
+	switch n.ShortTag() {
+	case "!!null", "!!bool", "!!int", "!!float":
+		var v any
+		if err := n.Decode(&v); err != nil {
+			return nil, err
+		}
+		out, err := marshalJSON(v)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n.Line, err)
+		}
+		return out, nil
+	}
+	return marshalJSON(n.Value)
+}
+
+// marshalJSON is json.Marshal without HTML escaping, which config values
+// such as descriptions do not need.
+func marshalJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// generatedHeader is the first line of every file written by Encode.
+const generatedHeader = "# Generated by lazy.go — https://github.com/hadnu/lazy.go"
+
+// schemaModelines tell editors where to find the JSON Schema. YAML uses the
+// yaml-language-server convention, TOML the one taplo understands; JSON
+// has nowhere to put one without adding a key.
+var schemaModelines = map[Format]string{
+	FormatYAML: "# yaml-language-server: $schema=" + SchemaURL,
+	FormatTOML: "#:schema " + SchemaURL,
+}
+
+// Encode serialises cfg in the given format, headed by a generated-by line
+// and a schema reference where the format allows comments.
+func Encode(cfg *ProjectConfig, format Format) ([]byte, error) {
+	f := toYAMLFile(cfg)
+	doc := new(yaml.Node)
+	if err := doc.Encode(&f); err != nil {
+		return nil, fmt.Errorf("marshalling config: %w", err)
+	}
+	return encodeWithHeader(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{doc}}, format, true)
+}
+
+// encodeWithHeader encodes doc headed by the format's schema modeline and,
+// when generated is set, the generated-by line.
+func encodeWithHeader(doc *yaml.Node, format Format, generated bool) ([]byte, error) {
+	data, err := encodeDocument(doc, format)
+	if err != nil {
+		return nil, err
+	}
+	modeline, ok := schemaModelines[format]
+	if !ok {
+		return data, nil
+	}
+	header := modeline + "\n"
+	if generated {
+		header = generatedHeader + "\n" + header
+	}
+	return append([]byte(header), data...), nil
+}
+
+// Export writes cfg to path in the given format.
+func Export(cfg *ProjectConfig, path string, format Format) error {
+	data, err := Encode(cfg, format)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing config file: %w", err)
+	}
+	return nil
+}
+
+// Convert re-encodes a configuration document from one format to another.
+// The document is migrated and checked for unknown keys and mistyped values
+// like any other input, but need not be complete, so defaults files and
+// presets convert too. extends and comments are kept where the target
+// format can hold them, and the schema modeline is rewritten for it.
+func Convert(name string, data []byte, from, to Format) ([]byte, error) {
+	doc, err := parseDocument(data, from)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if _, err := Migrate(doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	errs := checkKnownFields(doc, reflect.TypeOf(yamlFile{}))
+	typeErrs, err := decodeErrors(doc, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: parsing %s: %w", name, from.name(), err)
+	}
+	if errs = append(errs, typeErrs...); len(errs) > 0 {
+		for _, e := range errs {
+			e.File = name
+		}
+		return nil, errs
+	}
+	generated := stripHeader(doc)
+	return encodeWithHeader(doc, to, generated)
+}
+
+// stripHeader removes the generated-by line and any schema modeline from
+// the comment heading a document, so Convert can write the target's own.
+// It reports whether the generated-by line was present.
+func stripHeader(doc *yaml.Node) (generated bool) {
+	strip := func(c string) string {
+		var keep []string
+		for _, line := range strings.Split(c, "\n") {
+			t := strings.TrimSpace(line)
+			if t == generatedHeader {
+				generated = true
+				continue
+			}
+			if strings.HasPrefix(t, "# yaml-language-server:") || strings.HasPrefix(t, "#:schema ") {
+				continue
+			}
+			keep = append(keep, line)
+		}
+		return strings.TrimSpace(strings.Join(keep, "\n"))
+	}
+	doc.HeadComment = strip(doc.HeadComment)
+	if root, err := rootMapping(doc); err == nil {
+		root.HeadComment = strip(root.HeadComment)
+		if len(root.Content) > 0 {
+			root.Content[0].HeadComment = strip(root.Content[0].HeadComment)
+		}
+	}
+	return generated
+}
@@ -0,0 +1,233 @@
+package config_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+func TestFormatOf(t *testing.T) {
+	cases := map[string]config.Format{
+		"lazygo.yml":  config.FormatYAML,
+		"lazygo.yaml": config.FormatYAML,
+		"lazygo.json": config.FormatJSON,
+		"lazygo.TOML": config.FormatTOML,
+		"lazygo":      config.FormatYAML,
+		"-":           config.FormatYAML,
+	}
+	for path, want := range cases {
+		if got := config.FormatOf(path); got != want {
+			t.Errorf("FormatOf(%q) = %q, want %q", path, got, want)
+		}
+	}
+	if _, err := config.ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(xml) succeeded, want error")
+	}
+}
+
+func TestExport_RoundTripEveryFormat(t *testing.T) {
+	original := validCfg()
+	original.Description = `Says "hi" \ waves`
+	original.TaskRunner = config.TaskRunnerJust
+	original.Features = config.Features{Docker: true, Tests: true}
+	original.GitHub = config.GitHubConfig{Enabled: true, Topics: []string{"go", "cli"}}
+
+	for _, format := range config.AllFormats() {
+		t.Run(string(format), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "lazygo."+string(format))
+			if err := config.Export(original, path, format); err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			loaded, err := config.LoadFromYAML(path)
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if !reflect.DeepEqual(original, loaded) {
+				t.Errorf("round-trip mismatch:\ngot:  %+v\nwant: %+v", loaded, original)
+			}
+		})
+	}
+}
+
+func TestLoad_StrictInEveryFormat(t *testing.T) {
+	cases := map[string]string{
+		"lazygo.json": `{
+  "schema_version": 1,
+  "project": {"name": "svc", "module_path": "github.com/x/svc", "type": "api", "colour": "red"}
+}`,
+		"lazygo.toml": `schema_version = 1
+
+[project]
+name = "svc"
+module_path = "github.com/x/svc"
+type = "api"
+colour = "red"
+`,
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := writeNamed(t, t.TempDir(), name, content)
+			_, diags, err := config.LoadWithDiagnostics(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fe := findField(diags, "project.colour")
+			if fe == nil {
+				t.Fatalf("no error for project.colour in:\n%v", diags)
+			}
+			if fe.File != path || fe.Line != 3 && fe.Line != 7 {
+				t.Errorf("error at %s:%d, want the key's line in %s", fe.File, fe.Line, path)
+			}
+		})
+	}
+}
+
+func TestLoad_SyntaxErrorsArePositioned(t *testing.T) {
+	cases := map[string]string{
+		"lazygo.json": "{\n  \"project\": {\n    \"name\": \"svc\",\n  }\n}\n",
+		"lazygo.toml": "[project]\nname = \"svc\"\nname = \"again\"\n",
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := writeNamed(t, t.TempDir(), name, content)
+			_, err := config.LoadFromYAML(path)
+			if err == nil || !strings.Contains(err.Error(), "line ") {
+				t.Errorf("error = %v, want a positioned syntax error", err)
+			}
+		})
+	}
+}
+
+func TestLoad_TOMLBeyondTheSubsetLazygoWrites(t *testing.T) {
+	path := writeNamed(t, t.TempDir(), "lazygo.toml", `schema_version = 1
+
+[project]
+name = "svc"
+module_path = "example.com/svc"
+type = "api"
+description = """
+A service with "quotes" \
+and a continued line."""
+
+[vars]
+released = 1979-05-27
+cron = '''
+0 * * * *'''
+`)
+	cfg, err := config.LoadFromYAML(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `A service with "quotes" and a continued line.`; cfg.Description != want {
+		t.Errorf("Description = %q, want %q", cfg.Description, want)
+	}
+	if cfg.Vars["released"] != "1979-05-27" || cfg.Vars["cron"] != "0 * * * *" {
+		t.Errorf("Vars = %v", cfg.Vars)
+	}
+
+	// Arrays of tables parse; the config has no field that takes one.
+	path = writeNamed(t, t.TempDir(), "lazygo.toml", "schema_version = 1\n\n[[github.topics]]\nname = \"go\"\n")
+	_, err = config.LoadFromYAML(path)
+	if err == nil || !strings.Contains(err.Error(), "lazygo.toml:3:1: error: cannot unmarshal !!map") {
+		t.Errorf("err = %v, want a type error at the array of tables", err)
+	}
+}
+
+func TestLoadData_ExtendsAcrossFormats(t *testing.T) {
+	dir := t.TempDir()
+	writeNamed(t, dir, "base.toml", `# Platform baseline.
+[project]
+author = "Platform Team"
+visibility = "internal"
+
+[features]
+sast = true
+`)
+	data := []byte(`{"extends": "base.toml", "project": {"name": "svc", "module_path": "example.com/svc", "type": "api"}}`)
+
+	cfg, diags, err := config.LoadData(filepath.Join(dir, "<stdin>"), data, config.FormatJSON, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg == nil {
+		t.Fatalf("load failed:\n%v", diags)
+	}
+	if cfg.Author != "Platform Team" || !cfg.Features.SAST {
+		t.Errorf("base values not inherited: %+v", cfg)
+	}
+}
+
+func TestConvert_KeepsCommentsAndExtends(t *testing.T) {
+	src := []byte(`# Generated by lazy.go — https://github.com/hadnu/lazy.go
+# yaml-language-server: $schema=` + config.SchemaURL + `
+schema_version: 1
+extends: base.yml
+project:
+    # Shown on the README.
+    name: svc
+    type: api # a REST API
+    module_path: example.com/svc
+github:
+    topics: [go, api]
+`)
+
+	out, err := config.Convert("lazygo.yml", src, config.FormatYAML, config.FormatTOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toml := string(out)
+	for _, want := range []string{
+		"#:schema " + config.SchemaURL,
+		`extends = "base.yml"`,
+		"# Shown on the README.\nname = \"svc\"",
+		`type = "api" # a REST API`,
+		"[github]\ntopics = [\"go\", \"api\"]",
+	} {
+		if !strings.Contains(toml, want) {
+			t.Errorf("TOML output missing %q:\n%s", want, toml)
+		}
+	}
+	if strings.Contains(toml, "yaml-language-server") {
+		t.Errorf("YAML modeline carried into TOML:\n%s", toml)
+	}
+
+	back, err := config.Convert("lazygo.toml", out, config.FormatTOML, config.FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(back), "{\n  \"schema_version\": 1,\n  \"extends\": \"base.yml\",") {
+		t.Errorf("unexpected JSON:\n%s", back)
+	}
+}
+
+func TestConvert_JSONToYAMLIsBlockStyle(t *testing.T) {
+	src := []byte(`{"schema_version": 1, "project": {"name": "svc", "module_path": "example.com/svc", "type": "api", "description": "true"}, "github": {"topics": ["go", "api"]}}`)
+	out, err := config.Convert("in.json", src, config.FormatJSON, config.FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	yml := string(out)
+	for _, want := range []string{
+		"\nproject:\n    name: svc\n",
+		"    description: \"true\"\n",
+		"    topics:\n        - go\n        - api\n",
+	} {
+		if !strings.Contains(yml, want) {
+			t.Errorf("YAML output missing %q:\n%s", want, yml)
+		}
+	}
+	if strings.ContainsAny(yml, "{[") {
+		t.Errorf("YAML output uses flow style:\n%s", yml)
+	}
+}
+
+func TestConvert_RejectsUnknownKeys(t *testing.T) {
+	_, err := config.Convert("in.json", []byte(`{"project": {"nmae": "svc"}}`), config.FormatJSON, config.FormatYAML)
+	verrs, ok := err.(config.ValidationErrors)
+	if !ok || findField(verrs, "project.nmae") == nil {
+		t.Errorf("err = %v, want an unknown-key error", err)
+	}
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSchemaVersion is the lazygo.yml schema version written by this build.
+// It only goes up for changes that need a migration: renamed, moved or
+// reinterpreted keys. New optional keys leave it alone; older builds already
+// reject them as unknown, and files without them mean what they always did.
+const CurrentSchemaVersion = 1
+
+// schemaVersionKey is the top-level key holding the document's schema version.
+const schemaVersionKey = "schema_version"
+
+// migration upgrades a lazygo.yml document by exactly one schema version,
+// editing the node tree in place so comments and key order survive.
+type migration func(doc *yaml.Node) error
+
+// migrations[i] upgrades a document from schema version i to i+1.
+var migrations = []migration{
+	0: migrateV0ToV1,
+}
+
+func init() {
+	if len(migrations) != CurrentSchemaVersion {
+		panic(fmt.Sprintf("lazy.go: %d schema migrations registered, want %d", len(migrations), CurrentSchemaVersion))
+	}
+}
+
+// migrateV0ToV1 stamps unversioned files. Version 0 is every lazygo.yml
+// written before schema_version existed; its layout is otherwise unchanged.
+func migrateV0ToV1(doc *yaml.Node) error {
+	return setSchemaVersion(doc, 1)
+}
+
+// Migrate upgrades a parsed lazygo.yml document to CurrentSchemaVersion and
+// returns the version it started from. Documents written by a newer lazy.go
+// are rejected rather than misread.
+func Migrate(doc *yaml.Node) (int, error) {
+	from, err := SchemaVersion(doc)
+	if err != nil {
+		return 0, err
+	}
+	if from > CurrentSchemaVersion {
+		return from, fmt.Errorf("lazygo.yml uses schema version %d, but this lazy.go only understands up to version %d; upgrade lazy.go to read it", from, CurrentSchemaVersion)
+	}
+	for v := from; v < CurrentSchemaVersion; v++ {
+		if err := migrations[v](doc); err != nil {
+			return from, fmt.Errorf("migrating schema v%d to v%d: %w", v, v+1, err)
+		}
+	}
+	return from, nil
+}
+
+// MigrateFile upgrades the configuration file at path in place, in the
+// format its extension names, preserving comments where the format has them.
+// The file is only rewritten when its schema version changes.
+func MigrateFile(path string) (from, to int, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("reading config file: %w", err)
+	}
+
+	format := FormatOf(path)
+	doc, err := parseDocument(data, format)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	from, err = Migrate(doc)
+	if err != nil {
+		return from, from, err
+	}
+	if from == CurrentSchemaVersion {
+		return from, from, nil
+	}
+
+	out, err := encodeDocument(doc, format)
+	if err != nil {
+		return from, from, err
+	}
+	if err := os.WriteFile(path, out, 0o644); err != nil {
+		return from, from, fmt.Errorf("writing config file: %w", err)
+	}
+	return from, CurrentSchemaVersion, nil
+}
+
+// SchemaVersion returns the schema version recorded in a lazygo.yml document,
+// or 0 when the key is absent.
+func SchemaVersion(doc *yaml.Node) (int, error) {
+	root, err := rootMapping(doc)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != schemaVersionKey {
+			continue
+		}
+		v, err := strconv.Atoi(root.Content[i+1].Value)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("line %d: %s must be a non-negative integer, got %q",
+				root.Content[i+1].Line, schemaVersionKey, root.Content[i+1].Value)
+		}
+		return v, nil
+	}
+	return 0, nil
+}
+
+// setSchemaVersion writes schema_version, inserting it as the first key when
+// missing. A comment heading the document stays at the top of the file.
+func setSchemaVersion(doc *yaml.Node, v int) error {
+	root, err := rootMapping(doc)
+	if err != nil {
+		return err
+	}
+	value := strconv.Itoa(v)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == schemaVersionKey {
+			root.Content[i+1].Value = value
+			return nil
+		}
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: schemaVersionKey}
+	val := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value}
+	if len(root.Content) > 0 {
+		key.HeadComment, root.Content[0].HeadComment = root.Content[0].HeadComment, ""
+	}
+	root.Content = append([]*yaml.Node{key, val}, root.Content...)
+	return nil
+}
+
+// rootMapping returns the top-level mapping of a parsed document.
+func rootMapping(doc *yaml.Node) (*yaml.Node, error) {
+	n := doc
+	if n.Kind == yaml.DocumentNode {
+		if len(n.Content) == 0 {
+			return nil, fmt.Errorf("empty YAML document")
+		}
+		n = n.Content[0]
+	}
+	if n.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping at the top level of lazygo.yml", n.Line)
+	}
+	return n, nil
+}
+
+// encodeNode serialises a node tree with the same indentation as ExportToYAML.
+func encodeNode(doc *yaml.Node) ([]byte, error) {
+	return encodeIndented(doc, 4)
+}
+
+// encodeIndented serialises doc as YAML indented by indent spaces.
+func encodeIndented(doc *yaml.Node, indent int) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,105 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+const legacyYAML = `# Why: legal asked for Apache-2.0.
+project:
+    name: sentinel
+    module_path: github.com/user/sentinel
+    type: api
+    license: apache-2.0 # keep in sync with NOTICE
+features:
+    docker: true
+`
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "lazygo.yml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFromYAML_LegacyFileWithoutVersion(t *testing.T) {
+	cfg, err := config.LoadFromYAML(writeFile(t, legacyYAML))
+	if err != nil {
+		t.Fatalf("LoadFromYAML: %v", err)
+	}
+	if cfg.Name != "sentinel" || !cfg.Features.Docker {
+		t.Errorf("legacy file misread: %+v", cfg)
+	}
+}
+
+func TestLoadFromYAML_RejectsNewerSchema(t *testing.T) {
+	path := writeFile(t, "schema_version: 999\n"+legacyYAML)
+	_, err := config.LoadFromYAML(path)
+	if err == nil {
+		t.Fatal("expected error for a newer schema version")
+	}
+	if !strings.Contains(err.Error(), "upgrade lazy.go") {
+		t.Errorf("error should tell the user to upgrade: %v", err)
+	}
+}
+
+func TestMigrateFile_PreservesComments(t *testing.T) {
+	path := writeFile(t, legacyYAML)
+
+	from, to, err := config.MigrateFile(path)
+	if err != nil {
+		t.Fatalf("MigrateFile: %v", err)
+	}
+	if from != 0 || to != config.CurrentSchemaVersion {
+		t.Errorf("MigrateFile = %d → %d, want 0 → %d", from, to, config.CurrentSchemaVersion)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{"# Why: legal asked for Apache-2.0.", "# keep in sync with NOTICE", "schema_version: 1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("migrated file missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.HasPrefix(out, "# Why") {
+		t.Errorf("head comment should stay at the top of the file:\n%s", out)
+	}
+}
+
+func TestMigrateFile_CurrentVersionUntouched(t *testing.T) {
+	content := "schema_version: 1\n" + legacyYAML
+	path := writeFile(t, content)
+
+	from, to, err := config.MigrateFile(path)
+	if err != nil {
+		t.Fatalf("MigrateFile: %v", err)
+	}
+	if from != to {
+		t.Errorf("expected no migration, got %d → %d", from, to)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != content {
+		t.Error("file at the current version should not be rewritten")
+	}
+}
+
+func TestExportToYAML_WritesSchemaVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazygo.yml")
+	cfg := &config.ProjectConfig{Name: "x", ModulePath: "github.com/u/x", Type: config.ProjectTypeCLI}
+	if err := config.ExportToYAML(cfg, path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "schema_version: 1") {
+		t.Errorf("exported file lacks schema_version:\n%s", data)
+	}
+}
@@ -31,6 +31,22 @@ const (
 	LicenseProprietary LicenseType = "proprietary"
 )
 
+// SPDX returns the SPDX license identifier used in package metadata.
+func (l LicenseType) SPDX() string {
+	switch l {
+	case LicenseMIT:
+		return "MIT"
+	case LicenseGPL3:
+		return "GPL-3.0-only"
+	case LicenseApache2:
+		return "Apache-2.0"
+	case LicenseProprietary:
+		return "LicenseRef-Proprietary"
+	default:
+		return "NOASSERTION"
+	}
+}
+
 // CriticalityLevel describes the operational risk of the project.
 type CriticalityLevel string
 
@@ -40,6 +56,48 @@ const (
 	CriticalitySecurity     CriticalityLevel = "security-critical"
 )
 
+// TaskRunner identifies the tool used to drive common development tasks.
+type TaskRunner string
+
+const (
+	TaskRunnerMake TaskRunner = "make"
+	TaskRunnerTask TaskRunner = "task"
+	TaskRunnerJust TaskRunner = "just"
+)
+
+// DockerBase selects the runtime base image of the generated Dockerfile.
+type DockerBase string
+
+const (
+	DockerBaseDistroless DockerBase = "distroless"
+	DockerBaseScratch    DockerBase = "scratch"
+)
+
+// HookManager identifies the tool that installs and runs git hooks.
+type HookManager string
+
+const (
+	HookManagerPreCommit HookManager = "pre-commit"
+	HookManagerLefthook  HookManager = "lefthook"
+)
+
+// Router selects the HTTP router of generated API servers.
+type Router string
+
+const (
+	RouterStdlib Router = "stdlib"
+	RouterChi    Router = "chi"
+)
+
+// QueueBackend selects the message queue a worker consumes jobs from.
+type QueueBackend string
+
+const (
+	QueueNone  QueueBackend = "none"
+	QueueRedis QueueBackend = "redis"
+	QueueNATS  QueueBackend = "nats"
+)
+
 // Features represents optional capabilities to enable in the project.
 type Features struct {
 	Docker         bool `yaml:"docker"`
@@ -49,27 +107,64 @@ type Features struct {
 	Dependabot     bool `yaml:"dependabot"`
 	Tests          bool `yaml:"tests"`
 	SAST           bool `yaml:"sast"`
+	Hooks          bool `yaml:"hooks"`
 }
 
 // ProjectConfig is the central configuration object for a lazy.go project.
 type ProjectConfig struct {
-	Name        string           `yaml:"name"`
-	ModulePath  string           `yaml:"module_path"`
-	Description string           `yaml:"description"`
-	Author      string           `yaml:"author"`
-	Type        ProjectType      `yaml:"type"`
-	Visibility  Visibility       `yaml:"visibility"`
-	License     LicenseType      `yaml:"license"`
-	Criticality CriticalityLevel `yaml:"criticality"`
-	Features    Features         `yaml:"features"`
-	GitHub      GitHubConfig     `yaml:"github"`
+	Name         string             `yaml:"name"`
+	ModulePath   string             `yaml:"module_path"`
+	Description  string             `yaml:"description"`
+	Author       string             `yaml:"author"`
+	Type         ProjectType        `yaml:"type"`
+	Visibility   Visibility         `yaml:"visibility"`
+	License      LicenseType        `yaml:"license"`
+	Criticality  CriticalityLevel   `yaml:"criticality"`
+	TaskRunner   TaskRunner         `yaml:"task_runner"`
+	Features     Features           `yaml:"features"`
+	Docker       DockerConfig       `yaml:"docker,omitempty"`
+	Hooks        HooksConfig        `yaml:"hooks,omitempty"`
+	CLI          CLIConfig          `yaml:"cli,omitempty"`
+	API          APIConfig          `yaml:"api,omitempty"`
+	Worker       WorkerConfig       `yaml:"worker,omitempty"`
+	GitHub       GitHubConfig       `yaml:"github"`
+	Vars         map[string]string  `yaml:"vars,omitempty"`
+	RequiredVars map[string]VarSpec `yaml:"required_vars,omitempty"`
+}
+
+// DockerConfig holds container image settings, used when Features.Docker is set.
+type DockerConfig struct {
+	Base DockerBase `yaml:"base,omitempty"`
+}
+
+// HooksConfig holds git hook settings, used when Features.Hooks is set.
+type HooksConfig struct {
+	Manager HookManager `yaml:"manager,omitempty"`
+}
+
+// CLIConfig holds settings for command-line projects.
+type CLIConfig struct {
+	Binary string `yaml:"binary,omitempty"`
+}
+
+// APIConfig holds settings for HTTP API projects.
+type APIConfig struct {
+	Router Router `yaml:"router,omitempty"`
+}
+
+// WorkerConfig holds settings for background worker projects.
+type WorkerConfig struct {
+	Queue QueueBackend `yaml:"queue,omitempty"`
 }
 
 // GitHubConfig holds repository creation settings.
 type GitHubConfig struct {
-	Enabled    bool     `yaml:"enabled"`
-	Topics     []string `yaml:"topics,omitempty"`
-	PushOnInit bool     `yaml:"push_on_init"`
+	Enabled       bool     `yaml:"enabled"`
+	Owner         string   `yaml:"owner,omitempty"`
+	Repo          string   `yaml:"repo,omitempty"`
+	Topics        []string `yaml:"topics,omitempty"`
+	DefaultBranch string   `yaml:"default_branch,omitempty"`
+	PushOnInit    bool     `yaml:"push_on_init"`
 }
 
 // IsPublic returns true if the project is intended for public consumption.
@@ -82,6 +177,82 @@ func (p *ProjectConfig) IsSecure() bool {
 	return p.Criticality == CriticalityProduction || p.Criticality == CriticalitySecurity
 }
 
+// Runner returns the configured task runner, defaulting to make.
+func (p *ProjectConfig) Runner() TaskRunner {
+	if p.TaskRunner == "" {
+		return TaskRunnerMake
+	}
+	return p.TaskRunner
+}
+
+// IsService returns true for long-running network services that listen on a port.
+func (p *ProjectConfig) IsService() bool {
+	switch p.Type {
+	case ProjectTypeAPI, ProjectTypeMicroservice, ProjectTypeWorker:
+		return true
+	}
+	return false
+}
+
+// DockerBase returns the configured runtime base image, defaulting to distroless.
+func (p *ProjectConfig) DockerBase() DockerBase {
+	if p.Docker.Base == "" {
+		return DockerBaseDistroless
+	}
+	return p.Docker.Base
+}
+
+// HookManager returns the configured git hook manager, defaulting to pre-commit.
+func (p *ProjectConfig) HookManager() HookManager {
+	if p.Hooks.Manager == "" {
+		return HookManagerPreCommit
+	}
+	return p.Hooks.Manager
+}
+
+// Binary returns the name of the built executable, defaulting to the
+// project name.
+func (p *ProjectConfig) Binary() string {
+	if p.CLI.Binary == "" {
+		return p.Name
+	}
+	return p.CLI.Binary
+}
+
+// RepoName returns the name of the GitHub repository, defaulting to the
+// project name.
+func (p *ProjectConfig) RepoName() string {
+	if p.GitHub.Repo == "" {
+		return p.Name
+	}
+	return p.GitHub.Repo
+}
+
+// DefaultBranch returns the repository's default branch, defaulting to main.
+func (p *ProjectConfig) DefaultBranch() string {
+	if p.GitHub.DefaultBranch == "" {
+		return "main"
+	}
+	return p.GitHub.DefaultBranch
+}
+
+// Router returns the configured HTTP router, defaulting to the standard
+// library's ServeMux.
+func (p *ProjectConfig) Router() Router {
+	if p.API.Router == "" {
+		return RouterStdlib
+	}
+	return p.API.Router
+}
+
+// Queue returns the configured worker queue backend, defaulting to none.
+func (p *ProjectConfig) Queue() QueueBackend {
+	if p.Worker.Queue == "" {
+		return QueueNone
+	}
+	return p.Worker.Queue
+}
+
 // AllProjectTypes returns all valid project type values.
 func AllProjectTypes() []ProjectType {
 	return []ProjectType{
@@ -103,3 +274,63 @@ func AllLicenses() []LicenseType {
 		LicenseProprietary,
 	}
 }
+
+// AllTaskRunners returns all valid task runner values.
+func AllTaskRunners() []TaskRunner {
+	return []TaskRunner{
+		TaskRunnerMake,
+		TaskRunnerTask,
+		TaskRunnerJust,
+	}
+}
+
+// AllDockerBases returns all valid Docker base image values.
+func AllDockerBases() []DockerBase {
+	return []DockerBase{
+		DockerBaseDistroless,
+		DockerBaseScratch,
+	}
+}
+
+// AllHookManagers returns all valid git hook manager values.
+func AllHookManagers() []HookManager {
+	return []HookManager{
+		HookManagerPreCommit,
+		HookManagerLefthook,
+	}
+}
+
+// AllRouters returns all valid HTTP router values.
+func AllRouters() []Router {
+	return []Router{
+		RouterStdlib,
+		RouterChi,
+	}
+}
+
+// AllQueueBackends returns all valid worker queue backend values.
+func AllQueueBackends() []QueueBackend {
+	return []QueueBackend{
+		QueueNone,
+		QueueRedis,
+		QueueNATS,
+	}
+}
+
+// AllVisibilities returns all valid visibility values.
+func AllVisibilities() []Visibility {
+	return []Visibility{
+		VisibilityPublic,
+		VisibilityInternal,
+		VisibilityPrivate,
+	}
+}
+
+// AllCriticalities returns all valid criticality values.
+func AllCriticalities() []CriticalityLevel {
+	return []CriticalityLevel{
+		CriticalityExperimental,
+		CriticalityProduction,
+		CriticalitySecurity,
+	}
+}
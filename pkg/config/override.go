@@ -0,0 +1,232 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// varsPrefix prefixes keys naming a template variable, e.g. vars.team.
+const varsPrefix = "vars."
+
+// Override sets one lazygo.yml key from outside the file, such as a command
+// line flag or an environment variable.
+type Override struct {
+	Key    string // dotted path or unambiguous leaf name, e.g. features.docker
+	Value  string // lists are comma-separated
+	Source string // where the value came from, e.g. --type or LAZYGO_TYPE
+}
+
+// Keys returns the dotted path of every settable lazygo.yml key, sorted.
+func Keys() []string {
+	leaves := leafTypes()
+	keys := make([]string, 0, len(leaves))
+	for k := range leaves {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ResolveKey expands key to its full dotted path. A bare leaf name such as
+// "criticality" is accepted when only one key ends in it; dashes may stand
+// in for underscores. Template variables are addressed as vars.<name>.
+func ResolveKey(key string) (string, error) {
+	path, fe := resolveKey(key)
+	if fe != nil {
+		return "", fe
+	}
+	return path, nil
+}
+
+func resolveKey(key string) (string, *FieldError) {
+	key = strings.ReplaceAll(strings.TrimSpace(key), "-", "_")
+	if name, ok := strings.CutPrefix(key, varsPrefix); ok {
+		if !ValidVarName(name) {
+			return "", &FieldError{Field: key, Message: fmt.Sprintf("invalid variable name %q", name)}
+		}
+		return key, nil
+	}
+	leaves := leafTypes()
+	if _, ok := leaves[key]; ok {
+		return key, nil
+	}
+
+	var matches []string
+	for path := range leaves {
+		if _, leaf := cutLast(path, "."); leaf == key {
+			matches = append(matches, path)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		candidates := Keys()
+		for _, path := range Keys() {
+			_, leaf := cutLast(path, ".")
+			candidates = append(candidates, leaf)
+		}
+		hint := suggest(key, candidates)
+		if full, fe := resolveKey(hint); hint != "" && fe == nil {
+			hint = full
+		}
+		return "", &FieldError{
+			Field:   key,
+			Message: fmt.Sprintf("unknown key %q", key),
+			Hint:    hint,
+		}
+	default:
+		sort.Strings(matches)
+		return "", &FieldError{
+			Field:   key,
+			Message: fmt.Sprintf("ambiguous key %q matches %s", key, strings.Join(matches, ", ")),
+		}
+	}
+}
+
+// ApplyOverrides sets each override on cfg in order, so later ones win.
+// Problems are reported against the override's Source.
+func ApplyOverrides(cfg *ProjectConfig, overrides ...Override) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	doc, errs := overrideDoc(overrides, nil)
+	f := toYAMLFile(cfg)
+	if err := doc.Decode(&f); err != nil {
+		return fmt.Errorf("applying overrides: %w", err)
+	}
+	merged := f.config()
+
+	sources := make(map[string]string)
+	for _, o := range overrides {
+		if key, err := ResolveKey(o.Key); err == nil {
+			sources[key] = o.Source
+		}
+	}
+	for _, e := range enumFields(merged) {
+		if source, ok := sources[e.Field]; ok {
+			e.File = source
+			errs = append(errs, e)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	*cfg = *merged
+	return nil
+}
+
+// overrideDoc builds a document setting every override, ready to merge over
+// a loaded file. Each created node is recorded in origin under its Source.
+func overrideDoc(overrides []Override, origin map[*yaml.Node]string) (*yaml.Node, ValidationErrors) {
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	leaves := leafTypes()
+	var errs ValidationErrors
+
+	for _, o := range overrides {
+		key, fe := resolveKey(o.Key)
+		if fe != nil {
+			fe.File = o.Source
+			errs = append(errs, fe)
+			continue
+		}
+		t, ok := leaves[key]
+		if !ok {
+			t = reflect.TypeOf("") // vars.<name>
+		}
+		val, err := scalarFor(t, o.Value)
+		if err != nil {
+			errs = append(errs, &FieldError{File: o.Source, Field: key, Message: err.Error()})
+			continue
+		}
+
+		parent := root
+		parts := strings.Split(key, ".")
+		for _, part := range parts[:len(parts)-1] {
+			i := mappingIndex(parent, part)
+			if i < 0 {
+				k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}
+				m := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				parent.Content = append(parent.Content, k, m)
+				i = len(parent.Content) - 2
+				if origin != nil {
+					origin[k], origin[m] = o.Source, o.Source
+				}
+			}
+			parent = parent.Content[i+1]
+		}
+		leaf := parts[len(parts)-1]
+		k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: leaf}
+		if i := mappingIndex(parent, leaf); i >= 0 {
+			parent.Content[i], parent.Content[i+1] = k, val
+		} else {
+			parent.Content = append(parent.Content, k, val)
+		}
+		if origin != nil {
+			origin[k] = o.Source
+			origin[val] = o.Source
+			for _, c := range val.Content {
+				origin[c] = o.Source
+			}
+		}
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}, errs
+}
+
+// scalarFor converts a string value into a node of the field's type.
+func scalarFor(t reflect.Type, value string) (*yaml.Node, error) {
+	value = strings.TrimSpace(value)
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("want true or false, got %q", value)
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(b)}, nil
+	case reflect.Int:
+		if _, err := strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("want an integer, got %q", value)
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value}, nil
+	case reflect.Slice:
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item})
+			}
+		}
+		return seq, nil
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	}
+}
+
+// leafTypes maps the dotted path of every settable key to its Go type.
+// schema_version and extends describe the file itself and are excluded.
+func leafTypes() map[string]reflect.Type {
+	leaves := make(map[string]reflect.Type)
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for name, ft := range yamlFields(t) {
+			path := joinPath(prefix, name)
+			if ft.Kind() == reflect.Map {
+				// Template variables are set one at a time, as vars.<name>.
+				continue
+			}
+			if ft.Kind() == reflect.Struct {
+				walk(ft, path)
+				continue
+			}
+			leaves[path] = ft
+		}
+	}
+	walk(reflect.TypeOf(yamlFile{}), "")
+	delete(leaves, schemaVersionKey)
+	delete(leaves, extendsKey)
+	return leaves
+}
@@ -0,0 +1,89 @@
+package config_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+func TestResolveKey(t *testing.T) {
+	cases := map[string]string{
+		"features.docker": "features.docker",
+		"criticality":     "project.criticality",
+		"task-runner":     "project.task_runner",
+		"docker":          "features.docker",
+		"base":            "docker.base",
+	}
+	for in, want := range cases {
+		got, err := config.ResolveKey(in)
+		if err != nil || got != want {
+			t.Errorf("ResolveKey(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+
+	_, err := config.ResolveKey("critcality")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "project.criticality"`) {
+		t.Errorf("unknown key error = %v", err)
+	}
+}
+
+func TestApplyOverrides_LaterWins(t *testing.T) {
+	cfg := &config.ProjectConfig{Type: config.ProjectTypeCLI}
+	err := config.ApplyOverrides(cfg,
+		config.Override{Key: "type", Value: "api", Source: "LAZYGO_TYPE"},
+		config.Override{Key: "type", Value: "worker", Source: "--type"},
+		config.Override{Key: "features.docker", Value: "true", Source: "--feature"},
+		config.Override{Key: "github.topics", Value: "go, cli", Source: "--github-topics"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Type != config.ProjectTypeWorker || !cfg.Features.Docker {
+		t.Errorf("overrides not applied: %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.GitHub.Topics, []string{"go", "cli"}) {
+		t.Errorf("topics = %q", cfg.GitHub.Topics)
+	}
+}
+
+func TestApplyOverrides_ReportsSource(t *testing.T) {
+	cfg := &config.ProjectConfig{}
+	err := config.ApplyOverrides(cfg,
+		config.Override{Key: "type", Value: "apii", Source: "--type"},
+		config.Override{Key: "features.docker", Value: "maybe", Source: "LAZYGO_FEATURE_DOCKER"},
+	)
+	if err == nil {
+		t.Fatal("want errors")
+	}
+	for _, want := range []string{`--type: error: project.type: unknown project type: "apii"`, "LAZYGO_FEATURE_DOCKER: error: features.docker: want true or false"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+	if cfg.Type != "" {
+		t.Error("cfg modified despite errors")
+	}
+}
+
+func TestLoadResolved_OverridesOnTop(t *testing.T) {
+	path := writeFile(t, "project:\n    module_path: example.com/svc\n    type: cli\n")
+	cfg, diags, err := config.LoadResolved(path, "",
+		config.Override{Key: "name", Value: "svc", Source: "--name"},
+		config.Override{Key: "criticality", Value: "bogus", Source: "--criticality"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg != nil {
+		t.Fatalf("want invalid criticality to fail the load")
+	}
+	e := findField(diags, "project.criticality")
+	if e == nil || e.File != "--criticality" {
+		t.Errorf("diagnostic not attributed to the flag: %v", diags)
+	}
+	if findField(diags, "project.name") != nil {
+		t.Errorf("name supplied by override still reported: %v", diags)
+	}
+}
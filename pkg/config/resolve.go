@@ -0,0 +1,279 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// extendsKey names the base file a lazygo.yml inherits from.
+const extendsKey = "extends"
+
+// UserDir returns lazy.go's per-user configuration directory,
+// $XDG_CONFIG_HOME/lazygo (~/.config/lazygo on Linux when unset).
+func UserDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locating user config directory: %w", err)
+	}
+	return filepath.Join(dir, "lazygo"), nil
+}
+
+// DefaultsPath returns the location of the user or organisation defaults
+// file, defaults.yml in UserDir.
+func DefaultsPath() (string, error) {
+	dir, err := UserDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "defaults.yml"), nil
+}
+
+// ApplyFile overlays the partial lazygo.yml at path, such as the defaults
+// file or a preset, onto cfg: keys the file sets replace cfg's values,
+// everything else is left alone. The file may use extends. A missing file
+// is not an error.
+func ApplyFile(cfg *ProjectConfig, path string) error {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	src, err := resolve(path, "")
+	if err != nil {
+		return err
+	}
+	if len(src.errs) > 0 {
+		return src.errs
+	}
+	return overlay(cfg, src.doc, path)
+}
+
+// ApplyYAML is ApplyFile for an in-memory document; name labels diagnostics.
+// The document may not use extends.
+func ApplyYAML(cfg *ProjectConfig, name string, data []byte) error {
+	doc := new(yaml.Node)
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return fmt.Errorf("%s: parsing YAML: %w", name, err)
+	}
+	if _, err := Migrate(doc); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	errs := checkKnownFields(doc, reflect.TypeOf(yamlFile{}))
+	typeErrs, err := decodeErrors(doc, name)
+	if err != nil {
+		return fmt.Errorf("%s: parsing YAML: %w", name, err)
+	}
+	errs = append(errs, typeErrs...)
+	if len(errs) > 0 {
+		for _, e := range errs {
+			e.File = name
+		}
+		return errs
+	}
+	root, _ := rootMapping(doc)
+	if mappingIndex(root, extendsKey) >= 0 {
+		return fmt.Errorf("%s: %s is only supported in files", name, extendsKey)
+	}
+	return overlay(cfg, doc, name)
+}
+
+// overlay decodes doc on top of cfg and rejects out-of-range enum values.
+func overlay(cfg *ProjectConfig, doc *yaml.Node, name string) error {
+	f := toYAMLFile(cfg)
+	if err := doc.Decode(&f); err != nil {
+		return fmt.Errorf("%s: parsing YAML: %w", name, err)
+	}
+	merged := f.config()
+	var errs ValidationErrors
+	for _, e := range enumFields(merged) {
+		// A partial file need not set the project type.
+		if e.Field == "project.type" && merged.Type == "" {
+			continue
+		}
+		e.File = name
+		errs = append(errs, e)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	*cfg = *merged
+	return nil
+}
+
+// Resolve returns the effective lazygo.yml for path: its extends chain and
+// the defaults file (when non-empty and present) deep-merged underneath it,
+// and overrides on top, encoded as YAML without the extends key.
+func Resolve(path, defaults string, overrides ...Override) ([]byte, error) {
+	src, err := resolve(path, defaults, overrides...)
+	if err != nil {
+		return nil, err
+	}
+	if len(src.errs) > 0 {
+		return nil, src.errs
+	}
+	return encodeNode(src.doc)
+}
+
+// source is a lazygo.yml document merged from several files.
+type source struct {
+	doc    *yaml.Node            // merged document
+	origin map[*yaml.Node]string // file each node was read from
+	errs   ValidationErrors      // unknown keys and type errors, per file
+}
+
+func resolve(path, defaults string, overrides ...Override) (*source, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	return resolveData(path, data, FormatOf(path), defaults, overrides...)
+}
+
+func resolveData(name string, data []byte, format Format, defaults string, overrides ...Override) (*source, error) {
+	src := &source{origin: make(map[*yaml.Node]string)}
+	doc, err := src.parse(name, data, format, nil)
+	if err != nil {
+		return nil, err
+	}
+	if defaults != "" {
+		if _, err := os.Stat(defaults); err == nil {
+			base, err := src.load(defaults, nil)
+			if err != nil {
+				return nil, fmt.Errorf("defaults %s: %w", defaults, err)
+			}
+			doc = merge(base, doc)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("reading defaults: %w", err)
+		}
+	}
+	if len(overrides) > 0 {
+		over, errs := overrideDoc(overrides, src.origin)
+		src.errs = append(src.errs, errs...)
+		doc = merge(doc, over)
+	}
+	src.doc = doc
+	return src, nil
+}
+
+// load reads one file in the format its extension names and parses it.
+// chain holds the files currently being loaded, to detect cycles.
+func (s *source) load(path string, chain []string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	return s.parse(path, data, FormatOf(path), chain)
+}
+
+// parse parses one document, migrates it, and merges it over the file it
+// extends. Any format may extend any other.
+func (s *source) parse(path string, data []byte, format Format, chain []string) (*yaml.Node, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(chain, abs) {
+		return nil, fmt.Errorf("extends cycle: %s", strings.Join(append(chain, abs), " -> "))
+	}
+	chain = append(chain, abs)
+
+	doc, err := parseDocument(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Migrate(doc); err != nil {
+		return nil, err
+	}
+
+	errs := checkKnownFields(doc, reflect.TypeOf(yamlFile{}))
+	typeErrs, err := decodeErrors(doc, path)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", format.name(), err)
+	}
+	for _, e := range append(errs, typeErrs...) {
+		e.File = path
+		s.errs = append(s.errs, e)
+	}
+	s.record(doc, path)
+
+	root, _ := rootMapping(doc)
+	ref, err := takeExtends(root)
+	if err != nil || ref == "" {
+		return doc, err
+	}
+	if !filepath.IsAbs(ref) {
+		ref = filepath.Join(filepath.Dir(path), ref)
+	}
+	base, err := s.load(ref, chain)
+	if err != nil {
+		return nil, fmt.Errorf("extends %s: %w", ref, err)
+	}
+	return merge(base, doc), nil
+}
+
+// record remembers which file every node of n came from.
+func (s *source) record(n *yaml.Node, path string) {
+	s.origin[n] = path
+	for _, c := range n.Content {
+		s.record(c, path)
+	}
+}
+
+// takeExtends removes the extends key from a root mapping and returns its value.
+func takeExtends(root *yaml.Node) (string, error) {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != extendsKey {
+			continue
+		}
+		val := root.Content[i+1]
+		if val.Kind != yaml.ScalarNode {
+			return "", fmt.Errorf("line %d: %s must be a file path", val.Line, extendsKey)
+		}
+		root.Content = slices.Delete(root.Content, i, i+2)
+		return val.Value, nil
+	}
+	return "", nil
+}
+
+// merge deep-merges the document over into base and returns base. Mappings
+// are merged key by key; any other value in over replaces the one in base.
+func merge(base, over *yaml.Node) *yaml.Node {
+	b, errB := rootMapping(base)
+	o, errO := rootMapping(over)
+	if errB != nil || errO != nil {
+		return over
+	}
+	mergeMapping(b, o)
+	return base
+}
+
+func mergeMapping(base, over *yaml.Node) {
+	for i := 0; i+1 < len(over.Content); i += 2 {
+		key, val := over.Content[i], over.Content[i+1]
+		j := mappingIndex(base, key.Value)
+		switch {
+		case j < 0:
+			base.Content = append(base.Content, key, val)
+		case base.Content[j+1].Kind == yaml.MappingNode && val.Kind == yaml.MappingNode:
+			mergeMapping(base.Content[j+1], val)
+		default:
+			base.Content[j], base.Content[j+1] = key, val
+		}
+	}
+}
+
+// mappingIndex returns the index of key in a mapping's content, or -1.
+func mappingIndex(m *yaml.Node, key string) int {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,171 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+const baseYAML = `# Platform team baseline.
+project:
+    author: Platform Team
+    visibility: internal
+    criticality: production
+features:
+    sast: true
+    github_actions: true
+`
+
+func writeNamed(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFromYAML_ExtendsDeepMerges(t *testing.T) {
+	dir := t.TempDir()
+	writeNamed(t, dir, "platform/base.yml", baseYAML)
+	path := writeNamed(t, dir, "svc/lazygo.yml", `extends: ../platform/base.yml
+project:
+    name: svc
+    module_path: example.com/svc
+    type: api
+    criticality: experimental
+features:
+    docker: true
+`)
+
+	cfg, err := config.LoadFromYAML(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Author != "Platform Team" || cfg.Visibility != config.VisibilityInternal {
+		t.Errorf("base project values not inherited: %+v", cfg)
+	}
+	if cfg.Criticality != config.CriticalityExperimental {
+		t.Errorf("criticality = %q, want the extending file to win", cfg.Criticality)
+	}
+	if !cfg.Features.SAST || !cfg.Features.GitHubActions || !cfg.Features.Docker {
+		t.Errorf("features not deep-merged: %+v", cfg.Features)
+	}
+}
+
+func TestLoadFromYAML_ExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeNamed(t, dir, "a.yml", "extends: b.yml\n")
+	path := writeNamed(t, dir, "b.yml", "extends: a.yml\n")
+
+	_, err := config.LoadFromYAML(path)
+	if err == nil || !strings.Contains(err.Error(), "extends cycle") {
+		t.Fatalf("want extends cycle error, got %v", err)
+	}
+}
+
+func TestLoadFromYAML_ErrorInBaseNamesBaseFile(t *testing.T) {
+	dir := t.TempDir()
+	base := writeNamed(t, dir, "base.yml", "project:\n    licence: mit\n")
+	path := writeNamed(t, dir, "lazygo.yml", "extends: base.yml\nproject:\n    name: x\n    module_path: example.com/x\n    type: cli\n")
+
+	_, diags, err := config.LoadWithDiagnostics(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := findField(diags, "project.licence")
+	if e == nil {
+		t.Fatalf("no diagnostic for project.licence: %v", diags)
+	}
+	if e.File != base || e.Line != 2 {
+		t.Errorf("diagnostic at %s:%d, want %s:2", e.File, e.Line, base)
+	}
+}
+
+func TestLoadResolved_DefaultsUnderneath(t *testing.T) {
+	dir := t.TempDir()
+	defaults := writeNamed(t, dir, "defaults.yml", baseYAML)
+	path := writeNamed(t, dir, "lazygo.yml", "project:\n    name: x\n    module_path: example.com/x\n    type: cli\n    author: Me\n")
+
+	cfg, _, err := config.LoadResolved(path, defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg == nil || cfg.Author != "Me" || !cfg.Features.SAST {
+		t.Errorf("defaults not layered under file: %+v", cfg)
+	}
+
+	cfg, _, err = config.LoadResolved(path, filepath.Join(dir, "missing.yml"))
+	if err != nil || cfg == nil {
+		t.Fatalf("missing defaults file should be ignored: %v", err)
+	}
+}
+
+func TestResolve_DropsExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeNamed(t, dir, "base.yml", baseYAML)
+	path := writeNamed(t, dir, "lazygo.yml", "extends: base.yml\nproject:\n    name: x\n")
+
+	out, err := config.Resolve(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(out)
+	if strings.Contains(got, "extends") {
+		t.Errorf("resolved config still has extends:\n%s", got)
+	}
+	for _, want := range []string{"name: x", "author: Platform Team", "sast: true"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("resolved config missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestApplyFile(t *testing.T) {
+	dir := t.TempDir()
+	path := writeNamed(t, dir, "defaults.yml", baseYAML)
+
+	cfg := &config.ProjectConfig{Features: config.Features{Tests: true}}
+	if err := config.ApplyFile(cfg, path); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Author != "Platform Team" || !cfg.Features.SAST {
+		t.Errorf("defaults not applied: %+v", cfg)
+	}
+	if cfg.Type != "" || !cfg.Features.Tests {
+		t.Errorf("values absent from defaults were overwritten: %+v", cfg)
+	}
+
+	if err := config.ApplyFile(cfg, filepath.Join(dir, "missing.yml")); err != nil {
+		t.Errorf("missing defaults file: %v", err)
+	}
+}
+
+func TestDefaultsPath_HonoursXDG(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("XDG_CONFIG_HOME only applies on Linux")
+	}
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	got, err := config.DefaultsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("/tmp/xdg", "lazygo", "defaults.yml"); got != want {
+		t.Errorf("DefaultsPath() = %q, want %q", got, want)
+	}
+}
+
+func TestApplyYAML_RejectsUnknownKeys(t *testing.T) {
+	cfg := &config.ProjectConfig{}
+	err := config.ApplyYAML(cfg, "preset.yml", []byte("project:\n    typ: api\n"))
+	if err == nil || !strings.Contains(err.Error(), `preset.yml:2:5`) {
+		t.Fatalf("want positioned unknown-key error, got %v", err)
+	}
+}
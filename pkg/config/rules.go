@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// rule inspects a config and reports the problems it finds.
+type rule func(cfg *ProjectConfig) ValidationErrors
+
+// rules lists every check run by Check, in reporting order.
+var rules = []rule{
+	requiredFields,
+	enumFields,
+	nameMatchesModule,
+	dependabotNeedsGitHub,
+	sastNeedsCI,
+	proprietaryPublic,
+	unusedSettings,
+	templateVars,
+}
+
+func errorf(field, format string, args ...any) *FieldError {
+	return &FieldError{Severity: SeverityError, Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+func warnf(field, format string, args ...any) *FieldError {
+	return &FieldError{Severity: SeverityWarning, Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+func requiredFields(cfg *ProjectConfig) ValidationErrors {
+	var errs ValidationErrors
+	if cfg.Name == "" {
+		errs = append(errs, errorf("project.name", "project name is required"))
+	}
+	if cfg.ModulePath == "" {
+		errs = append(errs, errorf("project.module_path", "module path is required"))
+	}
+	return errs
+}
+
+// enumFields rejects values outside the known sets. Optional enums may be
+// left empty; the type is always required.
+func enumFields(cfg *ProjectConfig) ValidationErrors {
+	var errs ValidationErrors
+	check := func(field, what string, ok bool, value, hint string) {
+		if !ok {
+			e := errorf(field, "unknown %s: %q", what, value)
+			e.Hint = hint
+			errs = append(errs, e)
+		}
+	}
+
+	check("project.type", "project type",
+		oneOf(cfg.Type, AllProjectTypes()), string(cfg.Type), suggestEnum(cfg.Type, AllProjectTypes()))
+	check("project.license", "license",
+		cfg.License == "" || oneOf(cfg.License, AllLicenses()), string(cfg.License), suggestEnum(cfg.License, AllLicenses()))
+	check("project.visibility", "visibility",
+		cfg.Visibility == "" || oneOf(cfg.Visibility, AllVisibilities()), string(cfg.Visibility), suggestEnum(cfg.Visibility, AllVisibilities()))
+	check("project.criticality", "criticality",
+		cfg.Criticality == "" || oneOf(cfg.Criticality, AllCriticalities()), string(cfg.Criticality), suggestEnum(cfg.Criticality, AllCriticalities()))
+	check("project.task_runner", "task runner",
+		cfg.TaskRunner == "" || oneOf(cfg.TaskRunner, AllTaskRunners()), string(cfg.TaskRunner), suggestEnum(cfg.TaskRunner, AllTaskRunners()))
+	check("docker.base", "docker base image",
+		cfg.Docker.Base == "" || oneOf(cfg.Docker.Base, AllDockerBases()), string(cfg.Docker.Base), suggestEnum(cfg.Docker.Base, AllDockerBases()))
+	check("hooks.manager", "hook manager",
+		cfg.Hooks.Manager == "" || oneOf(cfg.Hooks.Manager, AllHookManagers()), string(cfg.Hooks.Manager), suggestEnum(cfg.Hooks.Manager, AllHookManagers()))
+	check("api.router", "router",
+		cfg.API.Router == "" || oneOf(cfg.API.Router, AllRouters()), string(cfg.API.Router), suggestEnum(cfg.API.Router, AllRouters()))
+	check("worker.queue", "queue backend",
+		cfg.Worker.Queue == "" || oneOf(cfg.Worker.Queue, AllQueueBackends()), string(cfg.Worker.Queue), suggestEnum(cfg.Worker.Queue, AllQueueBackends()))
+	if cfg.CLI.Binary != "" && !validBinary.MatchString(cfg.CLI.Binary) {
+		errs = append(errs, errorf("cli.binary",
+			"binary names may contain only letters, digits, dots, dashes and underscores, and must start with a letter or digit"))
+	}
+	if cfg.GitHub.Repo != "" && !ValidRepoName(cfg.GitHub.Repo) {
+		errs = append(errs, errorf("github.repo",
+			"repository names may contain only letters, digits, dots, dashes and underscores (max 100 chars)"))
+	}
+	if cfg.GitHub.DefaultBranch != "" && !ValidBranchName(cfg.GitHub.DefaultBranch) {
+		errs = append(errs, errorf("github.default_branch",
+			"%q is not a valid git branch name", cfg.GitHub.DefaultBranch))
+	}
+	return errs
+}
+
+// validBinary matches executable names that are safe in every generated
+// build file.
+var validBinary = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+var (
+	validRepo   = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+	validBranch = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+)
+
+// ValidRepoName reports whether name is a repository name GitHub accepts.
+func ValidRepoName(name string) bool {
+	return validRepo.MatchString(name) && name != "." && name != ".."
+}
+
+// ValidBranchName reports whether name is a branch name git accepts and the
+// generated workflows can quote without escaping: no empty path components,
+// "..", trailing dot or slash, or .lock suffix.
+func ValidBranchName(name string) bool {
+	return validBranch.MatchString(name) &&
+		!strings.Contains(name, "..") && !strings.Contains(name, "//") && !strings.Contains(name, "/.") &&
+		!strings.HasSuffix(name, "/") && !strings.HasSuffix(name, ".") && !strings.HasSuffix(name, ".lock")
+}
+
+// nameMatchesModule warns when the project directory and the import path
+// disagree, which makes `go install` produce an unexpected binary name.
+func nameMatchesModule(cfg *ProjectConfig) ValidationErrors {
+	if cfg.Name == "" || cfg.ModulePath == "" {
+		return nil
+	}
+	last := path.Base(cfg.ModulePath)
+	if strings.EqualFold(last, cfg.Name) {
+		return nil
+	}
+	return ValidationErrors{warnf("project.name",
+		"name %q does not match the last module path segment %q", cfg.Name, last)}
+}
+
+// dependabotNeedsGitHub warns about Dependabot on a project nothing says is
+// on GitHub. GitHub Actions is taken as proof of hosting, as security
+// enforcement turns Dependabot on whenever Actions is.
+func dependabotNeedsGitHub(cfg *ProjectConfig) ValidationErrors {
+	if !cfg.Features.Dependabot || cfg.GitHub.Enabled || cfg.Features.GitHubActions ||
+		strings.HasPrefix(cfg.ModulePath, "github.com/") {
+		return nil
+	}
+	return ValidationErrors{warnf("features.dependabot",
+		"dependabot only runs on GitHub-hosted repositories, but github.enabled and features.github_actions are false and the module is not on github.com")}
+}
+
+func sastNeedsCI(cfg *ProjectConfig) ValidationErrors {
+	if !cfg.Features.SAST || cfg.Features.GitHubActions {
+		return nil
+	}
+	return ValidationErrors{warnf("features.sast",
+		"SAST is enabled but no CI pipeline runs it; enable features.github_actions")}
+}
+
+func proprietaryPublic(cfg *ProjectConfig) ValidationErrors {
+	if cfg.License != LicenseProprietary || cfg.Visibility != VisibilityPublic {
+		return nil
+	}
+	return ValidationErrors{warnf("project.license",
+		"proprietary license on a public repository grants nobody the right to use the code")}
+}
+
+// unusedSettings flags sections that have no effect because their feature is off.
+func unusedSettings(cfg *ProjectConfig) ValidationErrors {
+	var errs ValidationErrors
+	if cfg.Docker.Base != "" && !cfg.Features.Docker {
+		errs = append(errs, warnf("docker.base", "ignored because features.docker is false"))
+	}
+	if cfg.Hooks.Manager != "" && !cfg.Features.Hooks {
+		errs = append(errs, warnf("hooks.manager", "ignored because features.hooks is false"))
+	}
+	if cfg.CLI.Binary != "" && cfg.Type == ProjectTypeLibrary {
+		errs = append(errs, warnf("cli.binary", "ignored because libraries build no executable"))
+	}
+	if cfg.API.Router != "" && !cfg.IsService() {
+		errs = append(errs, warnf("api.router", "ignored because %s projects serve no HTTP API", cfg.Type))
+	}
+	if cfg.Worker.Queue != "" && cfg.Type != ProjectTypeWorker && cfg.Type != ProjectTypeMicroservice {
+		errs = append(errs, warnf("worker.queue", "ignored because %s projects have no worker", cfg.Type))
+	}
+	if cfg.GitHub.Owner != "" && !cfg.GitHub.Enabled {
+		errs = append(errs, warnf("github.owner", "ignored because github.enabled is false"))
+	}
+	if cfg.GitHub.Repo != "" && !cfg.GitHub.Enabled {
+		errs = append(errs, warnf("github.repo", "ignored because github.enabled is false"))
+	}
+	return errs
+}
@@ -0,0 +1,98 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+	"github.com/had-nu/lazy.go/pkg/security"
+)
+
+func validCfg() *config.ProjectConfig {
+	return &config.ProjectConfig{
+		Name:        "svc",
+		ModulePath:  "github.com/x/svc",
+		Type:        config.ProjectTypeAPI,
+		Visibility:  config.VisibilityPublic,
+		License:     config.LicenseMIT,
+		Criticality: config.CriticalityProduction,
+	}
+}
+
+func TestCheck_ValidConfigHasNoProblems(t *testing.T) {
+	if diags := config.Check(validCfg()); len(diags) > 0 {
+		t.Errorf("unexpected problems:\n%v", diags)
+	}
+}
+
+func TestCheck_Rules(t *testing.T) {
+	cases := []struct {
+		name     string
+		mutate   func(*config.ProjectConfig)
+		field    string
+		severity config.Severity
+	}{
+		{"unknown license", func(c *config.ProjectConfig) { c.License = "wtfpl" }, "project.license", config.SeverityError},
+		{"unknown visibility", func(c *config.ProjectConfig) { c.Visibility = "secret" }, "project.visibility", config.SeverityError},
+		{"unknown criticality", func(c *config.ProjectConfig) { c.Criticality = "high" }, "project.criticality", config.SeverityError},
+		{"name mismatch", func(c *config.ProjectConfig) { c.Name = "other" }, "project.name", config.SeverityWarning},
+		{"dependabot off GitHub", func(c *config.ProjectConfig) {
+			c.ModulePath = "gitlab.com/x/svc"
+			c.Features.Dependabot = true
+		}, "features.dependabot", config.SeverityWarning},
+		{"SAST without CI", func(c *config.ProjectConfig) { c.Features.SAST = true }, "features.sast", config.SeverityWarning},
+		{"proprietary public", func(c *config.ProjectConfig) { c.License = config.LicenseProprietary }, "project.license", config.SeverityWarning},
+		{"docker base without docker", func(c *config.ProjectConfig) { c.Docker.Base = config.DockerBaseScratch }, "docker.base", config.SeverityWarning},
+		{"unknown router", func(c *config.ProjectConfig) { c.API.Router = "gin" }, "api.router", config.SeverityError},
+		{"bad binary name", func(c *config.ProjectConfig) { c.CLI.Binary = "my tool" }, "cli.binary", config.SeverityError},
+		{"queue on an API", func(c *config.ProjectConfig) { c.Worker.Queue = config.QueueRedis }, "worker.queue", config.SeverityWarning},
+		{"owner without GitHub", func(c *config.ProjectConfig) { c.GitHub.Owner = "acme" }, "github.owner", config.SeverityWarning},
+		{"repo without GitHub", func(c *config.ProjectConfig) { c.GitHub.Repo = "svc-go" }, "github.repo", config.SeverityWarning},
+		{"bad repo name", func(c *config.ProjectConfig) {
+			c.GitHub.Enabled = true
+			c.GitHub.Repo = "acme/svc"
+		}, "github.repo", config.SeverityError},
+		{"bad default branch", func(c *config.ProjectConfig) { c.GitHub.DefaultBranch = "release..1" }, "github.default_branch", config.SeverityError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := validCfg()
+			tc.mutate(c)
+			diags := config.Check(c)
+			if len(diags) != 1 {
+				t.Fatalf("expected exactly one problem, got:\n%v", diags)
+			}
+			if diags[0].Field != tc.field || diags[0].Severity != tc.severity {
+				t.Errorf("got %v, want %s on %s", diags[0], tc.severity, tc.field)
+			}
+		})
+	}
+}
+
+func TestCheck_EnforcedConfigHasNoProblems(t *testing.T) {
+	c := validCfg()
+	c.ModulePath = "gitlab.com/x/svc"
+	c.Features.GitHubActions = true
+	security.EnforceSecurity(c)
+	if !c.Features.Dependabot {
+		t.Fatal("production with GitHub Actions should enforce Dependabot")
+	}
+	if diags := config.Check(c); len(diags) > 0 {
+		t.Errorf("enforced config has problems:\n%v", diags)
+	}
+}
+
+func TestValidationErrors_Fails(t *testing.T) {
+	c := validCfg()
+	c.Features.SAST = true
+	diags := config.Check(c)
+
+	if diags.Fails(false) {
+		t.Error("warnings alone should not fail outside strict mode")
+	}
+	if !diags.Fails(true) {
+		t.Error("warnings should fail in strict mode")
+	}
+	if err := config.Validate(c); err != nil {
+		t.Errorf("Validate should ignore warnings: %v", err)
+	}
+}
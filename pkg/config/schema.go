@@ -0,0 +1,186 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// SchemaURL is where the published JSON Schema for lazygo.yml lives. Exported
+// files reference it so editors running yaml-language-server can validate and
+// autocomplete them.
+const SchemaURL = "https://raw.githubusercontent.com/had-nu/lazy.go/main/lazygo.schema.json"
+
+// JSONSchema is the subset of JSON Schema (draft-07) used to describe lazygo.yml.
+// It declares no required keys: a file that extends another, or relies on the
+// defaults file, may legitimately leave any of them out.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 any                    `json:"type"` // a type name or a list of them
+	Enum                 []string               `json:"enum,omitempty"`
+	Minimum              *int                   `json:"minimum,omitempty"`
+	Maximum              *int                   `json:"maximum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	PatternProperties    map[string]*JSONSchema `json:"patternProperties,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+}
+
+// fieldDocs describes every key of lazygo.yml by its dotted path.
+var fieldDocs = map[string]string{
+	"schema_version": "Version of the lazygo.yml format. Older files are upgraded with `lazy.go config migrate`.",
+	"extends":        "Base lazygo.yml to inherit from, relative to this file. Its values are deep-merged underneath this file's.",
+
+	"project":             "Identity and classification of the generated project.",
+	"project.name":        "Project directory and binary name.",
+	"project.module_path": "Go module path, e.g. github.com/acme/sentinel.",
+	"project.description": "One-line summary used in the README and repository description.",
+	"project.author":      "Copyright holder written into the LICENSE and headers.",
+	"project.type":        "Kind of project to scaffold; selects the directory layout and entrypoint.",
+	"project.license":     "License applied to the project. Private projects are always proprietary; unset, one is suggested from the visibility and type.",
+	"project.visibility":  "Who the project is for; drives repository visibility and licensing.",
+	"project.criticality": "Operational criticality. `production` and `security-critical` enforce static analysis, SAST and tests, plus Dependabot with GitHub Actions; `security-critical` also enforces git hooks.",
+	"project.task_runner": "Task runner used for build, test and lint targets. Defaults to make.",
+
+	"features":                 "Optional capabilities to generate.",
+	"features.docker":          "Generate a multi-stage Dockerfile. Ignored for libraries.",
+	"features.github_actions":  "Generate a GitHub Actions CI workflow.",
+	"features.linting":         "Generate a golangci-lint configuration.",
+	"features.static_analysis": "Run golangci-lint in CI, with the linters in .golangci.yml.",
+	"features.dependabot":      "Generate a Dependabot configuration for Go modules and actions.",
+	"features.tests":           "Generate test scaffolding, fuzz and benchmark tests.",
+	"features.sast":            "Run gosec and govulncheck in CI.",
+	"features.hooks":           "Generate git hooks for formatting, linting and secret scanning.",
+
+	"docker":      "Container image settings, used when features.docker is set.",
+	"docker.base": "Runtime base image. Defaults to distroless.",
+
+	"hooks":         "Git hook settings, used when features.hooks is set.",
+	"hooks.manager": "Hook manager to configure. Defaults to pre-commit.",
+
+	"cli":        "Settings for command-line projects.",
+	"cli.binary": "Name of the built executable. Defaults to the project name.",
+
+	"api":        "Settings for HTTP API projects.",
+	"api.router": "HTTP router of the generated server. Defaults to the standard library's ServeMux.",
+
+	"worker":       "Settings for worker and microservice projects.",
+	"worker.queue": "Message queue the worker consumes jobs from. Defaults to none, polling on a timer.",
+
+	"github":                "GitHub repository creation settings.",
+	"github.enabled":        "Create a GitHub repository for the project.",
+	"github.owner":          "User or organisation to create the repository under. Defaults to the authenticated user.",
+	"github.repo":           "Repository name. Defaults to the project name.",
+	"github.topics":         "Repository topics.",
+	"github.default_branch": "Branch the initial commit is pushed to and CI runs on. Defaults to main.",
+	"github.push_on_init":   "Push the initial commit after creating the repository.",
+
+	"vars":   "Free-form values exposed to every template as .Vars, e.g. team or slack_channel.",
+	"vars.*": "A template variable. Declared variables must match their declared type.",
+
+	"required_vars":           "Template variables the project must set, usually declared by a preset or base file.",
+	"required_vars.*":         "Declaration of a required template variable.",
+	"required_vars.*.type":    "Type the value must parse as. Defaults to string.",
+	"required_vars.*.default": "Value used when vars does not set the variable.",
+	"required_vars.*.prompt":  "Question the wizard asks when the variable is missing.",
+}
+
+// fieldEnums lists the allowed values of every enumerated key.
+func fieldEnums() map[string][]string {
+	return map[string][]string{
+		"project.type":         enumStrings(AllProjectTypes()),
+		"project.license":      enumStrings(AllLicenses()),
+		"project.visibility":   enumStrings(AllVisibilities()),
+		"project.criticality":  enumStrings(AllCriticalities()),
+		"project.task_runner":  enumStrings(AllTaskRunners()),
+		"docker.base":          enumStrings(AllDockerBases()),
+		"hooks.manager":        enumStrings(AllHookManagers()),
+		"api.router":           enumStrings(AllRouters()),
+		"worker.queue":         enumStrings(AllQueueBackends()),
+		"required_vars.*.type": enumStrings(AllVarTypes()),
+	}
+}
+
+// anyScalarFields are strings that may be written as any YAML scalar, such
+// as a variable's value, whatever its declared type.
+var anyScalarFields = map[string]bool{
+	"vars.*":                  true,
+	"required_vars.*.default": true,
+}
+
+// Schema builds the JSON Schema for lazygo.yml from the types it decodes into.
+func Schema() *JSONSchema {
+	s := schemaFor(reflect.TypeOf(yamlFile{}), "", fieldEnums())
+	s.Schema = "http://json-schema.org/draft-07/schema#"
+	s.ID = SchemaURL
+	s.Title = "lazy.go project configuration"
+	s.Description = "Configuration read by `lazy.go init --from` and written by the wizard."
+
+	lowest, current := 0, CurrentSchemaVersion
+	v := s.Properties["schema_version"]
+	v.Minimum, v.Maximum = &lowest, &current
+	return s
+}
+
+// SchemaJSON returns the indented JSON encoding of Schema.
+func SchemaJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(Schema(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding schema: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+func schemaFor(t reflect.Type, path string, enums map[string][]string) *JSONSchema {
+	s := &JSONSchema{Description: fieldDocs[path]}
+	switch t.Kind() {
+	case reflect.Struct:
+		closed := false
+		s.Type = "object"
+		s.Properties = make(map[string]*JSONSchema)
+		s.AdditionalProperties = &closed
+		for name, ft := range yamlFields(t) {
+			s.Properties[name] = schemaFor(ft, joinPath(path, name), enums)
+		}
+	case reflect.Map:
+		// Keys are variable names; values are described once for all keys.
+		closed := false
+		s.Type = "object"
+		s.PatternProperties = map[string]*JSONSchema{
+			validVarName.String(): schemaFor(t.Elem(), joinPath(path, "*"), enums),
+		}
+		s.AdditionalProperties = &closed
+	case reflect.Slice:
+		s.Type = "array"
+		s.Items = schemaFor(t.Elem(), path+"[]", enums)
+	case reflect.Bool:
+		s.Type = "boolean"
+	case reflect.Int, reflect.Int64:
+		s.Type = "integer"
+	default:
+		s.Type = "string"
+		s.Enum = enums[path]
+		if anyScalarFields[path] {
+			s.Type = []string{"string", "number", "boolean"}
+		}
+	}
+	return s
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func enumStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+func TestSchema_EveryFieldDescribed(t *testing.T) {
+	var walk func(s *config.JSONSchema, path string)
+	walk = func(s *config.JSONSchema, path string) {
+		for name, p := range s.Properties {
+			field := strings.TrimPrefix(path+"."+name, ".")
+			if p.Description == "" {
+				t.Errorf("%s has no description", field)
+			}
+			walk(p, field)
+		}
+	}
+	walk(config.Schema(), "")
+}
+
+func TestSchema_Enums(t *testing.T) {
+	s := config.Schema()
+	project := s.Properties["project"]
+
+	var types []string
+	for _, pt := range config.AllProjectTypes() {
+		types = append(types, string(pt))
+	}
+	if got := project.Properties["type"].Enum; !slices.Equal(got, types) {
+		t.Errorf("project.type enum = %v, want %v", got, types)
+	}
+	for _, field := range []string{"license", "visibility", "criticality", "task_runner"} {
+		if len(project.Properties[field].Enum) == 0 {
+			t.Errorf("project.%s has no enum", field)
+		}
+	}
+	if len(s.Properties["docker"].Properties["base"].Enum) == 0 {
+		t.Error("docker.base has no enum")
+	}
+	if ap := project.AdditionalProperties; ap == nil || *ap {
+		t.Error("project should reject unknown keys")
+	}
+}
+
+func TestSchema_PublishedCopyUpToDate(t *testing.T) {
+	want, err := config.SchemaJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../lazygo.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("lazygo.schema.json is stale; regenerate with `go run . schema > lazygo.schema.json`")
+	}
+}
+
+func TestExportToYAML_ReferencesSchema(t *testing.T) {
+	path := writeFile(t, "")
+	cfg := &config.ProjectConfig{Name: "x", ModulePath: "example.com/x", Type: config.ProjectTypeCLI}
+	if err := config.ExportToYAML(cfg, path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "# yaml-language-server: $schema="+config.SchemaURL+"\n") {
+		t.Errorf("missing schema modeline:\n%s", data)
+	}
+}
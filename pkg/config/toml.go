@@ -0,0 +1,452 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"gopkg.in/yaml.v3"
+)
+
+// TOML documents are decoded with go-toml and converted into the same
+// yaml.Node tree YAML files produce, so every later stage — migration,
+// unknown-key checks, positioned diagnostics — is shared. Dates and times
+// have no YAML counterpart the config uses and are kept as strings.
+//
+// Comments are only reachable through go-toml's unstable package, which has
+// no compatibility promise: go.mod pins go-toml, and toml_test.go covers the
+// round trip so an upgrade that changes the AST fails there first.
+
+// tomlError is a TOML error at a position in the input.
+type tomlError struct {
+	line, col int
+	msg       string
+}
+
+func (e *tomlError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.line, e.col, e.msg)
+}
+
+// tomlBuilder converts the expressions of a TOML document into a yaml.Node
+// tree, keeping positions and comments.
+type tomlBuilder struct {
+	p           *unstable.Parser
+	root        *yaml.Node
+	table       *yaml.Node // table that key/values are currently added to
+	comments    []string   // comment lines awaiting the next key
+	commentLine int        // line of the last comment in comments
+}
+
+// parseTOML parses a TOML document into a yaml.v3 document node.
+func parseTOML(data []byte) (*yaml.Node, error) {
+	// The decoder checks what the AST alone does not: duplicate keys,
+	// redefined tables and values that are out of range.
+	var v map[string]any
+	if err := toml.Unmarshal(data, &v); err != nil {
+		var de *toml.DecodeError
+		if errors.As(err, &de) {
+			line, col := de.Position()
+			return nil, &tomlError{line: line, col: col, msg: strings.TrimPrefix(de.Error(), "toml: ")}
+		}
+		return nil, err
+	}
+
+	p := &unstable.Parser{KeepComments: true}
+	p.Reset(data)
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: 1, Column: 1}
+	b := &tomlBuilder{p: p, root: root, table: root}
+	for p.NextExpression() {
+		if err := b.expression(p.Expression()); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.Error(); err != nil {
+		return nil, err
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}, Line: 1, Column: 1}, nil
+}
+
+// position returns where n starts, or fallback when the parser does not
+// record a range for n (arrays and table headers).
+func (b *tomlBuilder) position(n *unstable.Node, fallback *yaml.Node) (line, col int) {
+	if n.Raw.Length == 0 {
+		return fallback.Line, fallback.Column
+	}
+	s := b.p.Shape(n.Raw)
+	return s.Start.Line, s.Start.Column
+}
+
+func (b *tomlBuilder) expression(expr *unstable.Node) error {
+	switch expr.Kind {
+	case unstable.Comment:
+		line, _ := b.position(expr, b.root)
+		b.detachComments(line)
+		b.comments = append(b.comments, comment(expr))
+		b.commentLine = line
+		return nil
+	case unstable.Table, unstable.ArrayTable:
+		keys := b.keys(expr.Key())
+		k := keys[len(keys)-1]
+		table := b.root
+		for _, part := range keys[:len(keys)-1] {
+			table = b.descend(table, part)
+		}
+		if expr.Kind == unstable.Table {
+			table = b.descend(table, k)
+		} else {
+			table = b.appendTable(table, k)
+		}
+		if c := expr.Next(); c != nil && c.Kind == unstable.Comment {
+			k.LineComment = comment(c)
+		}
+		b.table = table
+		return nil
+	case unstable.KeyValue:
+		val, err := b.keyValue(b.table, expr)
+		if err != nil {
+			return err
+		}
+		if c := expr.Next(); c != nil && c.Kind == unstable.Comment {
+			val.LineComment = comment(c)
+		}
+		return nil
+	}
+	return nil
+}
+
+// detachComments drops pending comments separated by a blank line from
+// the key or comment on line, except for the comment block at the very
+// top of the file.
+func (b *tomlBuilder) detachComments(line int) {
+	if len(b.comments) > 0 && line > b.commentLine+1 && len(b.root.Content) > 0 {
+		b.comments = nil
+	}
+}
+
+func (b *tomlBuilder) attachComments(k *yaml.Node) {
+	b.detachComments(k.Line)
+	if len(b.comments) > 0 {
+		k.HeadComment = strings.Join(b.comments, "\n")
+		b.comments = nil
+	}
+}
+
+func comment(n *unstable.Node) string {
+	return strings.TrimRight(string(n.Data), "\r")
+}
+
+func (b *tomlBuilder) keys(it unstable.Iterator) []*yaml.Node {
+	var keys []*yaml.Node
+	for it.Next() {
+		n := it.Node()
+		line, col := b.position(n, b.root)
+		keys = append(keys, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: string(n.Data), Line: line, Column: col})
+	}
+	return keys
+}
+
+// descend returns the table under key k in parent, creating it if needed.
+// Under an array of tables it is the last table of the array.
+func (b *tomlBuilder) descend(parent, k *yaml.Node) *yaml.Node {
+	if i := mappingIndex(parent, k.Value); i >= 0 {
+		child := parent.Content[i+1]
+		if child.Kind == yaml.SequenceNode && len(child.Content) > 0 {
+			return child.Content[len(child.Content)-1]
+		}
+		return child
+	}
+	b.attachComments(k)
+	child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: k.Line, Column: k.Column}
+	parent.Content = append(parent.Content, k, child)
+	return child
+}
+
+// appendTable adds a table to the array of tables under key k in parent.
+func (b *tomlBuilder) appendTable(parent, k *yaml.Node) *yaml.Node {
+	var seq *yaml.Node
+	if i := mappingIndex(parent, k.Value); i >= 0 {
+		seq = parent.Content[i+1]
+	} else {
+		b.attachComments(k)
+		seq = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: k.Line, Column: k.Column}
+		parent.Content = append(parent.Content, k, seq)
+	}
+	table := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: k.Line, Column: k.Column}
+	seq.Content = append(seq.Content, table)
+	return table
+}
+
+// keyValue adds the key/value expr to table, creating the tables of a
+// dotted key.
+func (b *tomlBuilder) keyValue(table *yaml.Node, expr *unstable.Node) (*yaml.Node, error) {
+	keys := b.keys(expr.Key())
+	for _, part := range keys[:len(keys)-1] {
+		table = b.descend(table, part)
+	}
+	k := keys[len(keys)-1]
+	b.attachComments(k)
+	val, err := b.value(expr.Value(), k)
+	if err != nil {
+		return nil, err
+	}
+	table.Content = append(table.Content, k, val)
+	return val, nil
+}
+
+// value converts a TOML value; k positions values the parser has no range
+// for.
+func (b *tomlBuilder) value(n *unstable.Node, k *yaml.Node) (*yaml.Node, error) {
+	line, col := b.position(n, k)
+	node := &yaml.Node{Kind: yaml.ScalarNode, Line: line, Column: col}
+	data := string(n.Data)
+	switch n.Kind {
+	case unstable.String:
+		node.Tag, node.Value = "!!str", data
+	case unstable.Bool:
+		node.Tag, node.Value = "!!bool", data
+	case unstable.Integer:
+		i, err := strconv.ParseInt(strings.ReplaceAll(data, "_", ""), 0, 64)
+		if err != nil {
+			return nil, &tomlError{line: line, col: col, msg: fmt.Sprintf("invalid integer %q", data)}
+		}
+		node.Tag, node.Value = "!!int", strconv.FormatInt(i, 10)
+	case unstable.Float:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(data, "_", ""), 64)
+		if err != nil {
+			return nil, &tomlError{line: line, col: col, msg: fmt.Sprintf("invalid float %q", data)}
+		}
+		node.Tag, node.Value = "!!float", yamlFloat(f)
+	case unstable.LocalDate, unstable.LocalTime, unstable.LocalDateTime, unstable.DateTime:
+		node.Tag, node.Value = "!!str", data
+	case unstable.Array:
+		node.Kind, node.Tag = yaml.SequenceNode, "!!seq"
+		for it := n.Children(); it.Next(); {
+			if it.Node().Kind == unstable.Comment {
+				continue
+			}
+			item, err := b.value(it.Node(), node)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, item)
+		}
+	case unstable.InlineTable:
+		node.Kind, node.Tag, node.Style = yaml.MappingNode, "!!map", yaml.FlowStyle
+		for it := n.Children(); it.Next(); {
+			if it.Node().Kind != unstable.KeyValue {
+				continue
+			}
+			if _, err := b.keyValue(node, it.Node()); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, &tomlError{line: line, col: col, msg: fmt.Sprintf("unsupported value %q", data)}
+	}
+	return node, nil
+}
+
+// yamlFloat formats f as a YAML float, which spells infinity and NaN
+// differently from TOML.
+func yamlFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return ".inf"
+	case math.IsInf(f, -1):
+		return "-.inf"
+	case math.IsNaN(f):
+		return ".nan"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// encodeTOML writes a yaml.v3 document as TOML. Scalars and arrays of the
+// root mapping come first, then one [table] per nested mapping. Comments
+// are carried over; null values are omitted, as TOML has no null.
+func encodeTOML(doc *yaml.Node) ([]byte, error) {
+	root, err := rootMapping(doc)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if doc.HeadComment != "" {
+		writeComment(&buf, doc.HeadComment)
+		buf.WriteString("\n")
+	}
+	if err := encodeTOMLTable(&buf, root, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeTOMLTable(buf *bytes.Buffer, table *yaml.Node, path []string) error {
+	// Plain keys first: TOML assigns every key after a header to that table.
+	for i := 0; i+1 < len(table.Content); i += 2 {
+		k, v := table.Content[i], table.Content[i+1]
+		if v.Kind == yaml.MappingNode || isTableArray(v) || isNull(v) {
+			continue
+		}
+		val, err := tomlValue(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(append(path, k.Value), "."), err)
+		}
+		writeComment(buf, k.HeadComment)
+		fmt.Fprintf(buf, "%s = %s", tomlKey(k.Value), val)
+		if c := firstNonEmpty(v.LineComment, k.LineComment); c != "" {
+			buf.WriteString(" " + c)
+		}
+		buf.WriteString("\n")
+	}
+	for i := 0; i+1 < len(table.Content); i += 2 {
+		k, v := table.Content[i], table.Content[i+1]
+		sub := append(append([]string(nil), path...), k.Value)
+		quoted := make([]string, len(sub))
+		for j, s := range sub {
+			quoted[j] = tomlKey(s)
+		}
+		header := "[" + strings.Join(quoted, ".") + "]"
+		tables := []*yaml.Node{v}
+		switch {
+		case isTableArray(v):
+			header, tables = "["+header+"]", v.Content
+		case v.Kind != yaml.MappingNode:
+			continue
+		case onlyTables(v):
+			// [a.b] defines a implicitly; its comment heads the first sub-table.
+			first := v.Content[0]
+			var c []string
+			for _, s := range []string{k.HeadComment, k.LineComment, first.HeadComment} {
+				if s != "" {
+					c = append(c, s)
+				}
+			}
+			first.HeadComment = strings.Join(c, "\n")
+			if err := encodeTOMLTable(buf, v, sub); err != nil {
+				return err
+			}
+			continue
+		}
+		for j, t := range tables {
+			if buf.Len() > 0 {
+				buf.WriteString("\n")
+			}
+			if j == 0 {
+				writeComment(buf, k.HeadComment)
+			}
+			buf.WriteString(header)
+			if c := firstNonEmpty(k.LineComment, t.LineComment); c != "" && j == 0 {
+				buf.WriteString(" " + c)
+			}
+			buf.WriteString("\n")
+			if err := encodeTOMLTable(buf, t, sub); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isTableArray reports whether v is a non-empty sequence of mappings,
+// written as an array of tables.
+func isTableArray(v *yaml.Node) bool {
+	if v.Kind != yaml.SequenceNode || len(v.Content) == 0 {
+		return false
+	}
+	for _, item := range v.Content {
+		if item.Kind != yaml.MappingNode {
+			return false
+		}
+	}
+	return true
+}
+
+// onlyTables reports whether the mapping v holds nothing but tables and
+// so needs no header of its own.
+func onlyTables(v *yaml.Node) bool {
+	if len(v.Content) == 0 {
+		return false
+	}
+	for i := 1; i < len(v.Content); i += 2 {
+		if c := v.Content[i]; c.Kind != yaml.MappingNode && !isTableArray(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func tomlValue(v *yaml.Node) (string, error) {
+	switch v.Kind {
+	case yaml.ScalarNode:
+		switch v.ShortTag() {
+		case "!!bool", "!!int", "!!float":
+			return v.Value, nil
+		}
+		return tomlString(v.Value), nil
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(v.Content))
+		for _, item := range v.Content {
+			s, err := tomlValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case yaml.AliasNode:
+		return tomlValue(v.Alias)
+	}
+	return "", fmt.Errorf("cannot be represented in TOML")
+}
+
+// tomlString quotes s as a TOML basic string. JSON string escapes are a
+// subset of TOML's.
+func tomlString(s string) string {
+	out, _ := marshalJSON(s)
+	return string(out)
+}
+
+func tomlKey(k string) string {
+	if k == "" {
+		return `""`
+	}
+	for i := 0; i < len(k); i++ {
+		if !isBareKeyChar(k[i]) {
+			return tomlString(k)
+		}
+	}
+	return k
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func isNull(v *yaml.Node) bool {
+	return v.Kind == yaml.ScalarNode && v.ShortTag() == "!!null"
+}
+
+func writeComment(buf *bytes.Buffer, c string) {
+	if c == "" {
+		return
+	}
+	for _, line := range strings.Split(c, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			line = "# " + line
+		}
+		buf.WriteString(line + "\n")
+	}
+}
+
+func firstNonEmpty(s ...string) string {
+	for _, v := range s {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+// tomlConfig exercises what the TOML encoder has to get right: comments,
+// nested tables, string arrays and keys that cannot be written bare.
+const tomlConfig = `# Team service.
+schema_version = 1
+
+# Identity.
+[project]
+name = "svc" # directory name
+module_path = "example.com/svc"
+type = "api"
+description = "Says \"hi\" \\ waves\ttabs"
+
+[github]
+topics = ["go", "api", "with space"]
+
+[vars]
+team = "payments"
+"slack.channel" = "#alerts"
+"on call" = ""
+"ünïcode" = "yes"
+
+# Asked for by the wizard.
+[required_vars.region]
+type = "string"
+default = "eu-west-1"
+`
+
+func TestConvert_TOMLRoundTripIsStable(t *testing.T) {
+	out, err := config.Convert("lazygo.toml", []byte(tomlConfig), config.FormatTOML, config.FormatTOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toml := string(out)
+	for _, want := range []string{
+		"#:schema " + config.SchemaURL + "\n# Team service.\n",
+		"# Identity.\n[project]\nname = \"svc\" # directory name\n",
+		`description = "Says \"hi\" \\ waves\ttabs"`,
+		"[github]\ntopics = [\"go\", \"api\", \"with space\"]\n",
+		"[vars]\nteam = \"payments\"\n\"slack.channel\" = \"#alerts\"\n\"on call\" = \"\"\n\"ünïcode\" = \"yes\"\n",
+		"# Asked for by the wizard.\n[required_vars.region]\ntype = \"string\"\ndefault = \"eu-west-1\"\n",
+	} {
+		if !strings.Contains(toml, want) {
+			t.Errorf("TOML output missing %q:\n%s", want, toml)
+		}
+	}
+
+	if strings.Contains(toml, "[required_vars]\n") {
+		t.Errorf("header written for a table holding only tables:\n%s", toml)
+	}
+
+	again, err := config.Convert("lazygo.toml", out, config.FormatTOML, config.FormatTOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != toml {
+		t.Errorf("second round trip changed the output:\n%s\nwant:\n%s", again, toml)
+	}
+}
+
+func TestConvert_TOMLThroughYAMLKeepsValues(t *testing.T) {
+	yml, err := config.Convert("lazygo.toml", []byte(tomlConfig), config.FormatTOML, config.FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toml, err := config.Convert("lazygo.yml", yml, config.FormatYAML, config.FormatTOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	json, err := config.Convert("lazygo.toml", toml, config.FormatTOML, config.FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"description": "Says \"hi\" \\ waves\ttabs"`,
+		`"topics": [` + "\n      \"go\",\n      \"api\",\n      \"with space\"\n    ]",
+		`"slack.channel": "#alerts"`,
+		`"on call": ""`,
+		`"ünïcode": "yes"`,
+		`"required_vars": {` + "\n    \"region\": {\n      \"type\": \"string\",\n      \"default\": \"eu-west-1\"",
+	} {
+		if !strings.Contains(string(json), want) {
+			t.Errorf("JSON output missing %q:\n%s", want, json)
+		}
+	}
+}
+
+func TestLoadData_TOMLQuotedKeys(t *testing.T) {
+	data := []byte("schema_version = 1\n\n[project]\nname = \"svc\"\nmodule_path = \"example.com/svc\"\ntype = \"api\"\n\n[vars]\n\"team\" = \"payments\"\n'on_call' = \"ops\"\n")
+	cfg, diags, err := config.LoadData("lazygo.toml", data, config.FormatTOML, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg == nil {
+		t.Fatalf("load failed:\n%v", diags)
+	}
+	if cfg.Vars["team"] != "payments" || cfg.Vars["on_call"] != "ops" {
+		t.Errorf("Vars = %v", cfg.Vars)
+	}
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity ranks how serious a FieldError is.
+type Severity int
+
+const (
+	// SeverityError marks a config that cannot be used as-is.
+	SeverityError Severity = iota
+	// SeverityWarning marks a likely mistake that does not block generation
+	// unless strict mode is requested.
+	SeverityWarning
+)
+
+// String returns the lowercase label used in diagnostics.
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// FieldError describes a problem with a single lazygo.yml field. Position
+// fields are filled in when the config was loaded from a file.
+type FieldError struct {
+	Severity Severity
+	Field    string // dotted YAML path, e.g. "project.type"
+	Message  string
+	Hint     string // optional "did you mean" suggestion
+	File     string
+	Line     int
+	Column   int
+}
+
+// Error formats the problem like a compiler diagnostic:
+// file:line:col: severity: field: message (did you mean "x"?).
+func (e *FieldError) Error() string {
+	var sb strings.Builder
+	if e.File != "" {
+		sb.WriteString(e.File + ":")
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, "%d:%d:", e.Line, e.Column)
+	}
+	if sb.Len() > 0 {
+		sb.WriteString(" ")
+	}
+	sb.WriteString(e.Severity.String() + ": ")
+	if e.Field != "" {
+		sb.WriteString(e.Field + ": ")
+	}
+	sb.WriteString(e.Message)
+	if e.Hint != "" {
+		fmt.Fprintf(&sb, " (did you mean %q?)", e.Hint)
+	}
+	return sb.String()
+}
+
+// ValidationErrors aggregates every problem found in a config, errors and
+// warnings alike.
+type ValidationErrors []*FieldError
+
+// Error joins all problems, one per line.
+func (v ValidationErrors) Error() string {
+	lines := make([]string, len(v))
+	for i, e := range v {
+		lines[i] = e.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Errors returns only the problems of error severity.
+func (v ValidationErrors) Errors() ValidationErrors {
+	return v.filter(SeverityError)
+}
+
+// Warnings returns only the problems of warning severity.
+func (v ValidationErrors) Warnings() ValidationErrors {
+	return v.filter(SeverityWarning)
+}
+
+// Fails reports whether the problems should block generation. In strict
+// mode warnings count as failures too.
+func (v ValidationErrors) Fails(strict bool) bool {
+	if strict {
+		return len(v) > 0
+	}
+	return len(v.Errors()) > 0
+}
+
+func (v ValidationErrors) filter(sev Severity) ValidationErrors {
+	var out ValidationErrors
+	for _, e := range v {
+		if e.Severity == sev {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Check runs every validation rule against cfg and returns all problems found.
+func Check(cfg *ProjectConfig) ValidationErrors {
+	var all ValidationErrors
+	for _, r := range rules {
+		all = append(all, r(cfg)...)
+	}
+	return all
+}
+
+// Validate checks that cfg is usable. Every error-severity problem is
+// reported, not just the first; warnings are ignored (see Check). The
+// returned error is a ValidationErrors when non-nil.
+func Validate(cfg *ProjectConfig) error {
+	if errs := Check(cfg).Errors(); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func oneOf[T comparable](v T, valid []T) bool {
+	for _, x := range valid {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
+
+func suggestEnum[T ~string](v T, valid []T) string {
+	candidates := make([]string, len(valid))
+	for i, x := range valid {
+		candidates[i] = string(x)
+	}
+	return suggest(string(v), candidates)
+}
+
+// suggest returns the candidate closest to input, or "" when none is close
+// enough to be a plausible typo.
+func suggest(input string, candidates []string) string {
+	if input == "" {
+		return ""
+	}
+	input = strings.ToLower(input)
+	best, bestDist := "", min(3, len(input)/2+1)+1
+	for _, c := range candidates {
+		if d := levenshtein(input, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
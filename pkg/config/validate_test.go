@@ -0,0 +1,90 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+// loadErrors loads content from a temp file and returns its validation errors.
+func loadErrors(t *testing.T, content string) config.ValidationErrors {
+	t.Helper()
+	_, err := config.LoadFromYAML(writeFile(t, content))
+	var verrs config.ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	return verrs
+}
+
+func findField(verrs config.ValidationErrors, field string) *config.FieldError {
+	for _, e := range verrs {
+		if e.Field == field {
+			return e
+		}
+	}
+	return nil
+}
+
+func TestLoadFromYAML_UnknownKeys(t *testing.T) {
+	verrs := loadErrors(t, `schema_version: 1
+project:
+    name: x
+    module_path: github.com/u/x
+    type: cli
+    criticallity: production
+features:
+    dockr: true
+`)
+
+	cases := []struct {
+		field      string
+		line, col  int
+		suggestion string
+	}{
+		{"project.criticallity", 6, 5, "criticality"},
+		{"features.dockr", 8, 5, "docker"},
+	}
+	for _, c := range cases {
+		e := findField(verrs, c.field)
+		if e == nil {
+			t.Errorf("no error reported for %s: %v", c.field, verrs)
+			continue
+		}
+		if e.Line != c.line || e.Column != c.col {
+			t.Errorf("%s at %d:%d, want %d:%d", c.field, e.Line, e.Column, c.line, c.col)
+		}
+		if e.Hint != c.suggestion {
+			t.Errorf("%s hint = %q, want %q", c.field, e.Hint, c.suggestion)
+		}
+	}
+}
+
+func TestLoadFromYAML_AggregatesErrors(t *testing.T) {
+	verrs := loadErrors(t, `project:
+    type: apii
+features:
+    tests: maybe
+`)
+
+	for _, field := range []string{"project.name", "project.module_path", "project.type", "features.tests"} {
+		if findField(verrs, field) == nil {
+			t.Errorf("expected an error for %s, got:\n%v", field, verrs)
+		}
+	}
+	if e := findField(verrs, "project.type"); e != nil && e.Hint != "api" {
+		t.Errorf("project.type hint = %q, want %q", e.Hint, "api")
+	}
+}
+
+func TestFieldError_Format(t *testing.T) {
+	e := &config.FieldError{
+		File: "lazygo.yml", Line: 4, Column: 11,
+		Field: "project.type", Message: `unknown project type: "apii"`, Hint: "api",
+	}
+	want := `lazygo.yml:4:11: error: project.type: unknown project type: "apii" (did you mean "api"?)`
+	if got := e.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
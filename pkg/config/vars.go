@@ -0,0 +1,156 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VarType is the type of a declared template variable.
+type VarType string
+
+const (
+	VarTypeString VarType = "string"
+	VarTypeInt    VarType = "int"
+	VarTypeBool   VarType = "bool"
+)
+
+// AllVarTypes returns every supported template variable type.
+func AllVarTypes() []VarType {
+	return []VarType{VarTypeString, VarTypeInt, VarTypeBool}
+}
+
+// VarSpec declares a template variable a project must define. Presets,
+// defaults files and base files list the variables their templates rely on
+// under required_vars; the project sets them under vars.
+type VarSpec struct {
+	Type    VarType `yaml:"type,omitempty"`    // defaults to string
+	Default string  `yaml:"default,omitempty"` // used when vars does not set it
+	Prompt  string  `yaml:"prompt,omitempty"`  // question asked by the wizard
+}
+
+// validVarName matches names usable as {{.Vars.name}} in a template.
+var validVarName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidVarName reports whether name can be used as a template variable.
+func ValidVarName(name string) bool {
+	return validVarName.MatchString(name)
+}
+
+// ParseVar converts a raw value to the type spec declares: int, bool or
+// string.
+func ParseVar(spec VarSpec, value string) (any, error) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(string(spec.Type)) {
+	case string(VarTypeInt):
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("want an integer, got %q", value)
+		}
+		return n, nil
+	case string(VarTypeBool):
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("want true or false, got %q", value)
+		}
+		return b, nil
+	default:
+		return value, nil
+	}
+}
+
+// MissingVars returns the sorted names of required variables that are
+// neither set nor defaulted.
+func (c *ProjectConfig) MissingVars() []string {
+	var missing []string
+	for name, spec := range c.RequiredVars {
+		if _, ok := c.Vars[name]; !ok && spec.Default == "" {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// TemplateVars returns the variables exposed to templates as .Vars: every
+// entry of vars, plus the defaults of required variables left unset.
+// Declared variables are converted to their type; a value that does not
+// parse, which Check reports, is passed through as a string.
+func (c *ProjectConfig) TemplateVars() map[string]any {
+	out := make(map[string]any, len(c.Vars)+len(c.RequiredVars))
+	for name, v := range c.Vars {
+		out[name] = v
+	}
+	for name, spec := range c.RequiredVars {
+		raw, ok := c.Vars[name]
+		if !ok {
+			if spec.Default == "" {
+				continue
+			}
+			raw = spec.Default
+		}
+		if v, err := ParseVar(spec, raw); err == nil {
+			out[name] = v
+		} else {
+			out[name] = raw
+		}
+	}
+	return out
+}
+
+// templateVars checks variable names, declared types and values, and that
+// every required variable is set.
+func templateVars(cfg *ProjectConfig) ValidationErrors {
+	var errs ValidationErrors
+	for _, name := range sortedKeys(cfg.Vars) {
+		if !ValidVarName(name) {
+			errs = append(errs, errorf("vars."+name,
+				"variable names must start with a letter or underscore and contain only letters, digits and underscores"))
+		}
+	}
+	for _, name := range sortedKeys(cfg.RequiredVars) {
+		spec := cfg.RequiredVars[name]
+		field := "required_vars." + name
+		if !ValidVarName(name) {
+			errs = append(errs, errorf(field,
+				"variable names must start with a letter or underscore and contain only letters, digits and underscores"))
+			continue
+		}
+		if spec.Type != "" && !oneOf(VarType(strings.ToLower(string(spec.Type))), AllVarTypes()) {
+			e := errorf(field+".type", "unknown variable type: %q", spec.Type)
+			e.Hint = suggestEnum(spec.Type, AllVarTypes())
+			errs = append(errs, e)
+			continue
+		}
+		if spec.Default != "" {
+			if _, err := ParseVar(spec, spec.Default); err != nil {
+				errs = append(errs, errorf(field+".default", "%v", err))
+			}
+		}
+		v, ok := cfg.Vars[name]
+		switch {
+		case ok:
+			if _, err := ParseVar(spec, v); err != nil {
+				errs = append(errs, errorf("vars."+name, "%v", err))
+			}
+		case spec.Default == "":
+			msg := "required variable is not set"
+			if spec.Prompt != "" {
+				msg += " (" + spec.Prompt + ")"
+			}
+			errs = append(errs, errorf("vars."+name, "%s", msg))
+		}
+	}
+	return errs
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
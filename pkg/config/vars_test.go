@@ -0,0 +1,112 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+func TestCheck_TemplateVars(t *testing.T) {
+	cfg := validCfg()
+	cfg.Vars = map[string]string{"replicas": "three", "team": "payments", "bad-name": "x"}
+	cfg.RequiredVars = map[string]config.VarSpec{
+		"replicas": {Type: config.VarTypeInt},
+		"oncall":   {Prompt: "On-call rotation"},
+		"pager":    {Type: "boolean"},
+		"region":   {Default: "eu-west-1"},
+	}
+
+	diags := config.Check(cfg)
+	for field, want := range map[string]string{
+		"vars.bad-name":            "variable names must start with a letter or underscore and contain only letters, digits and underscores",
+		"vars.replicas":            `want an integer, got "three"`,
+		"vars.oncall":              "required variable is not set (On-call rotation)",
+		"required_vars.pager.type": `unknown variable type: "boolean"`,
+	} {
+		fe := findField(diags, field)
+		if fe == nil {
+			t.Errorf("no problem reported for %s in:\n%v", field, diags)
+			continue
+		}
+		if fe.Message != want {
+			t.Errorf("%s: message = %q, want %q", field, fe.Message, want)
+		}
+	}
+	if fe := findField(diags, "vars.region"); fe != nil {
+		t.Errorf("defaulted variable reported: %v", fe)
+	}
+	if got := cfg.MissingVars(); !reflect.DeepEqual(got, []string{"oncall", "pager"}) {
+		t.Errorf("MissingVars = %v, want [oncall pager]", got)
+	}
+}
+
+func TestTemplateVars_TypedWithDefaults(t *testing.T) {
+	cfg := validCfg()
+	cfg.Vars = map[string]string{"team": "payments", "replicas": "3"}
+	cfg.RequiredVars = map[string]config.VarSpec{
+		"replicas": {Type: config.VarTypeInt},
+		"pager":    {Type: config.VarTypeBool, Default: "true"},
+		"oncall":   {Prompt: "On-call rotation"},
+	}
+
+	want := map[string]any{"team": "payments", "replicas": 3, "pager": true}
+	if got := cfg.TemplateVars(); !reflect.DeepEqual(got, want) {
+		t.Errorf("TemplateVars = %v, want %v", got, want)
+	}
+}
+
+func TestLoadFromYAML_Vars(t *testing.T) {
+	dir := t.TempDir()
+	writeNamed(t, dir, "base.yml", `required_vars:
+    team:
+        prompt: Owning team
+    cost_centre:
+        type: int
+        promt: Cost centre
+`)
+	path := writeNamed(t, dir, "lazygo.yml", `extends: base.yml
+project:
+    name: svc
+    module_path: github.com/x/svc
+    type: api
+vars:
+    team: payments
+    cost_centre: 4200
+`)
+
+	_, diags, err := config.LoadWithDiagnostics(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fe := findField(diags, "required_vars.cost_centre.promt")
+	if fe == nil || fe.Hint != "prompt" {
+		t.Fatalf("want an unknown-key error with a hint inside required_vars, got:\n%v", diags)
+	}
+
+	writeNamed(t, dir, "base.yml", "required_vars:\n    team:\n        prompt: Owning team\n")
+	cfg, err := config.LoadFromYAML(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Vars["cost_centre"] != "4200" || cfg.RequiredVars["team"].Prompt != "Owning team" {
+		t.Errorf("vars not loaded: %v %v", cfg.Vars, cfg.RequiredVars)
+	}
+}
+
+func TestResolveKey_Vars(t *testing.T) {
+	if got, err := config.ResolveKey("vars.slack-channel"); err != nil || got != "vars.slack_channel" {
+		t.Errorf("ResolveKey(vars.slack-channel) = %q, %v", got, err)
+	}
+	if _, err := config.ResolveKey("vars.9lives"); err == nil {
+		t.Error("ResolveKey accepted an invalid variable name")
+	}
+
+	cfg := validCfg()
+	if err := config.ApplyOverrides(cfg, config.Override{Key: "vars.team", Value: "payments", Source: "--var team=payments"}); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Vars["team"] != "payments" {
+		t.Errorf("vars = %v", cfg.Vars)
+	}
+}
@@ -1,8 +1,14 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -10,7 +16,9 @@ import (
 
 // yamlProject mirrors ProjectConfig for YAML serialization.
 type yamlFile struct {
-	Project struct {
+	SchemaVersion int    `yaml:"schema_version"`
+	Extends       string `yaml:"extends,omitempty"`
+	Project       struct {
 		Name        string `yaml:"name"`
 		ModulePath  string `yaml:"module_path"`
 		Description string `yaml:"description"`
@@ -19,46 +27,118 @@ type yamlFile struct {
 		License     string `yaml:"license"`
 		Visibility  string `yaml:"visibility"`
 		Criticality string `yaml:"criticality"`
+		TaskRunner  string `yaml:"task_runner,omitempty"`
 	} `yaml:"project"`
-	Features Features     `yaml:"features"`
-	GitHub   GitHubConfig `yaml:"github"`
+	Features     Features           `yaml:"features"`
+	Docker       DockerConfig       `yaml:"docker,omitempty"`
+	Hooks        HooksConfig        `yaml:"hooks,omitempty"`
+	CLI          CLIConfig          `yaml:"cli,omitempty"`
+	API          APIConfig          `yaml:"api,omitempty"`
+	Worker       WorkerConfig       `yaml:"worker,omitempty"`
+	GitHub       GitHubConfig       `yaml:"github"`
+	Vars         map[string]string  `yaml:"vars,omitempty"`
+	RequiredVars map[string]VarSpec `yaml:"required_vars,omitempty"`
 }
 
 // LoadFromYAML reads a lazygo.yml file and returns a ProjectConfig.
+// Unknown keys are rejected. Error-severity problems are returned together
+// as ValidationErrors, each carrying its file position; warnings are dropped
+// (use LoadWithDiagnostics to see them).
 func LoadFromYAML(path string) (*ProjectConfig, error) {
-	data, err := os.ReadFile(path)
+	cfg, diags, err := LoadWithDiagnostics(path)
 	if err != nil {
-		return nil, fmt.Errorf("reading config file: %w", err)
+		return nil, err
+	}
+	if errs := diags.Errors(); len(errs) > 0 {
+		return nil, errs
 	}
+	return cfg, nil
+}
 
-	var f yamlFile
-	if err := yaml.Unmarshal(data, &f); err != nil {
-		return nil, fmt.Errorf("parsing YAML: %w", err)
+// LoadWithDiagnostics reads a lazygo.yml file and returns the config along
+// with every problem found, errors and warnings, positioned in the file.
+// The config is nil when any problem has error severity. The error result
+// is reserved for failures to read or parse the file at all.
+func LoadWithDiagnostics(path string) (*ProjectConfig, ValidationErrors, error) {
+	return LoadResolved(path, "")
+}
+
+// LoadResolved is LoadWithDiagnostics with a defaults file layered underneath
+// the file and everything it extends, and overrides applied on top. An empty
+// or missing defaults path is ignored. Diagnostics name the file, or the
+// override source, each offending value came from.
+func LoadResolved(path, defaults string, overrides ...Override) (*ProjectConfig, ValidationErrors, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading config file: %w", err)
 	}
+	return LoadData(path, data, FormatOf(path), defaults, overrides...)
+}
 
-	cfg := &ProjectConfig{
-		Name:        f.Project.Name,
-		ModulePath:  f.Project.ModulePath,
-		Description: f.Project.Description,
-		Author:      f.Project.Author,
-		Type:        ProjectType(strings.ToLower(f.Project.Type)),
-		License:     LicenseType(strings.ToLower(f.Project.License)),
-		Visibility:  Visibility(strings.ToLower(f.Project.Visibility)),
-		Criticality: CriticalityLevel(strings.ToLower(f.Project.Criticality)),
-		Features:    f.Features,
-		GitHub:      f.GitHub,
+// LoadData is LoadResolved for a document already in memory, such as one
+// read from stdin, in an explicit format. name labels diagnostics, and
+// relative extends paths are resolved against its directory.
+func LoadData(name string, data []byte, format Format, defaults string, overrides ...Override) (*ProjectConfig, ValidationErrors, error) {
+	src, err := resolveData(name, data, format, defaults, overrides...)
+	if err != nil {
+		return nil, nil, err
 	}
+	errs := src.errs
 
-	if err := Validate(cfg); err != nil {
-		return nil, fmt.Errorf("invalid config: %w", err)
+	// Type errors were already reported against the file they occur in.
+	var f yamlFile
+	if err := src.doc.Decode(&f); err != nil && !errors.As(err, new(*yaml.TypeError)) {
+		return nil, nil, fmt.Errorf("parsing YAML: %w", err)
 	}
 
-	return cfg, nil
+	cfg := f.config()
+	errs = append(errs, Check(cfg)...)
+
+	if len(errs) > 0 {
+		positions := nodePositions(src.doc)
+		for _, e := range errs {
+			if e.File == "" {
+				e.File = name
+			}
+			if e.Line == 0 {
+				// Missing fields point at their closest enclosing key.
+				for field := e.Field; field != ""; field, _ = cutLast(field, ".") {
+					if n, ok := positions[field]; ok {
+						if origin := src.origin[n]; origin != "" {
+							e.File = origin
+						}
+						e.Line, e.Column = n.Line, n.Column
+						break
+					}
+				}
+			}
+		}
+		sort.SliceStable(errs, func(i, j int) bool {
+			a, b := errs[i], errs[j]
+			if (a.File == name) != (b.File == name) {
+				return a.File == name
+			}
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		})
+	}
+	if len(errs.Errors()) > 0 {
+		return nil, errs, nil
+	}
+	return cfg, errs, nil
 }
 
 // ExportToYAML writes a ProjectConfig to a lazygo.yml file.
 func ExportToYAML(cfg *ProjectConfig, path string) error {
+	return Export(cfg, path, FormatYAML)
+}
+
+// toYAMLFile converts cfg to its on-disk layout at the current schema version.
+func toYAMLFile(cfg *ProjectConfig) yamlFile {
 	var f yamlFile
+	f.SchemaVersion = CurrentSchemaVersion
 	f.Project.Name = cfg.Name
 	f.Project.ModulePath = cfg.ModulePath
 	f.Project.Description = cfg.Description
@@ -67,36 +147,176 @@ func ExportToYAML(cfg *ProjectConfig, path string) error {
 	f.Project.License = string(cfg.License)
 	f.Project.Visibility = string(cfg.Visibility)
 	f.Project.Criticality = string(cfg.Criticality)
+	f.Project.TaskRunner = string(cfg.TaskRunner)
 	f.Features = cfg.Features
+	f.Docker = cfg.Docker
+	f.Hooks = cfg.Hooks
+	f.CLI = cfg.CLI
+	f.API = cfg.API
+	f.Worker = cfg.Worker
 	f.GitHub = cfg.GitHub
+	// Copied so that decoding a partial file over f leaves cfg untouched.
+	f.Vars = maps.Clone(cfg.Vars)
+	f.RequiredVars = maps.Clone(cfg.RequiredVars)
+	return f
+}
+
+// config converts the on-disk layout to a ProjectConfig, normalising enum case.
+func (f *yamlFile) config() *ProjectConfig {
+	return &ProjectConfig{
+		Name:         f.Project.Name,
+		ModulePath:   f.Project.ModulePath,
+		Description:  f.Project.Description,
+		Author:       f.Project.Author,
+		Type:         ProjectType(strings.ToLower(f.Project.Type)),
+		License:      LicenseType(strings.ToLower(f.Project.License)),
+		Visibility:   Visibility(strings.ToLower(f.Project.Visibility)),
+		Criticality:  CriticalityLevel(strings.ToLower(f.Project.Criticality)),
+		TaskRunner:   TaskRunner(strings.ToLower(f.Project.TaskRunner)),
+		Features:     f.Features,
+		Docker:       DockerConfig{Base: DockerBase(strings.ToLower(string(f.Docker.Base)))},
+		Hooks:        HooksConfig{Manager: HookManager(strings.ToLower(string(f.Hooks.Manager)))},
+		CLI:          f.CLI,
+		API:          APIConfig{Router: Router(strings.ToLower(string(f.API.Router)))},
+		Worker:       WorkerConfig{Queue: QueueBackend(strings.ToLower(string(f.Worker.Queue)))},
+		GitHub:       f.GitHub,
+		Vars:         f.Vars,
+		RequiredVars: f.RequiredVars,
+	}
+}
 
-	data, err := yaml.Marshal(&f)
+// checkKnownFields walks a YAML node tree alongside the Go type it decodes
+// into and reports every key that the type does not declare.
+func checkKnownFields(doc *yaml.Node, t reflect.Type) ValidationErrors {
+	root, err := rootMapping(doc)
 	if err != nil {
-		return fmt.Errorf("marshalling config: %w", err)
+		return nil
+	}
+	var errs ValidationErrors
+	walkKnownFields(root, t, "", &errs)
+	return errs
+}
+
+func walkKnownFields(n *yaml.Node, t reflect.Type, prefix string, errs *ValidationErrors) {
+	if n.Kind == yaml.MappingNode && t.Kind() == reflect.Map {
+		// Map keys are free-form; their values may still be structs.
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			walkKnownFields(n.Content[i+1], t.Elem(), prefix+n.Content[i].Value+".", errs)
+		}
+		return
 	}
+	if n.Kind != yaml.MappingNode || t.Kind() != reflect.Struct {
+		return
+	}
+	fields := yamlFields(t)
+	known := make([]string, 0, len(fields))
+	for name := range fields {
+		known = append(known, name)
+	}
+	sort.Strings(known)
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, val := n.Content[i], n.Content[i+1]
+		ft, ok := fields[key.Value]
+		if !ok {
+			*errs = append(*errs, &FieldError{
+				Field:   prefix + key.Value,
+				Message: fmt.Sprintf("unknown field %q", key.Value),
+				Hint:    suggest(key.Value, known),
+				Line:    key.Line,
+				Column:  key.Column,
+			})
+			continue
+		}
+		walkKnownFields(val, ft, prefix+key.Value+".", errs)
+	}
+}
 
-	header := "# Generated by lazy.go — https://github.com/hadnu/lazy.go\n"
-	if err := os.WriteFile(path, append([]byte(header), data...), 0o644); err != nil {
-		return fmt.Errorf("writing config file: %w", err)
+// yamlFields maps the YAML key of each exported field of t to its type.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
 	}
+	return fields
+}
 
-	return nil
+// nodePositions indexes the value node of every mapping key by its dotted path.
+func nodePositions(doc *yaml.Node) map[string]*yaml.Node {
+	positions := make(map[string]*yaml.Node)
+	root, err := rootMapping(doc)
+	if err != nil {
+		return positions
+	}
+	var walk func(n *yaml.Node, prefix string)
+	walk = func(n *yaml.Node, prefix string) {
+		if n.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			path := prefix + n.Content[i].Value
+			positions[path] = n.Content[i+1]
+			walk(n.Content[i+1], path+".")
+		}
+	}
+	walk(root, "")
+	return positions
 }
 
-// Validate checks that required ProjectConfig fields are set and valid.
-func Validate(cfg *ProjectConfig) error {
-	if cfg.Name == "" {
-		return fmt.Errorf("project name is required")
+// decodeErrors reports the yaml.v3 type errors of a single document, with
+// the field of each recovered from its line. Other decode failures are
+// returned as the error.
+func decodeErrors(doc *yaml.Node, path string) (ValidationErrors, error) {
+	var f yamlFile
+	err := doc.Decode(&f)
+	var typeErr *yaml.TypeError
+	if err == nil || !errors.As(err, &typeErr) {
+		return nil, err
 	}
-	if cfg.ModulePath == "" {
-		return fmt.Errorf("module path is required")
+	positions := nodePositions(doc)
+	var errs ValidationErrors
+	for _, msg := range typeErr.Errors {
+		e := typeError(msg)
+		e.File = path
+		for field, n := range positions {
+			if n.Line == e.Line && n.Kind == yaml.ScalarNode {
+				e.Field, e.Column = field, n.Column
+			}
+		}
+		errs = append(errs, e)
 	}
-	validTypes := map[ProjectType]bool{}
-	for _, t := range AllProjectTypes() {
-		validTypes[t] = true
+	return errs, nil
+}
+
+// yamlLinePrefix matches the "line N: " prefix of yaml.v3 type errors.
+var yamlLinePrefix = regexp.MustCompile(`^line (\d+): `)
+
+// typeError converts a yaml.v3 type error message into a FieldError.
+func typeError(msg string) *FieldError {
+	e := &FieldError{Message: msg}
+	if m := yamlLinePrefix.FindStringSubmatch(msg); m != nil {
+		e.Line, _ = strconv.Atoi(m[1])
+		e.Column = 1
+		e.Message = strings.TrimPrefix(msg, m[0])
 	}
-	if !validTypes[cfg.Type] {
-		return fmt.Errorf("unknown project type: %q", cfg.Type)
+	return e
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):]
 	}
-	return nil
+	return "", s
 }
@@ -19,6 +19,7 @@ func TestLoadFromYAML_RoundTrip(t *testing.T) {
 		Visibility:  config.VisibilityPublic,
 		License:     config.LicenseApache2,
 		Criticality: config.CriticalityProduction,
+		TaskRunner:  config.TaskRunnerTask,
 		Features: config.Features{
 			Docker:         true,
 			GitHubActions:  true,
@@ -92,6 +93,25 @@ func TestValidate_InvalidType(t *testing.T) {
 	}
 }
 
+func TestValidate_InvalidTaskRunner(t *testing.T) {
+	cfg := &config.ProjectConfig{
+		Name:       "valid",
+		ModulePath: "github.com/x/valid",
+		Type:       config.ProjectTypeCLI,
+		TaskRunner: config.TaskRunner("bazel"),
+	}
+	if err := config.Validate(cfg); err == nil {
+		t.Error("expected error for invalid task runner")
+	}
+}
+
+func TestRunner_DefaultsToMake(t *testing.T) {
+	cfg := &config.ProjectConfig{}
+	if got := cfg.Runner(); got != config.TaskRunnerMake {
+		t.Errorf("Runner() = %q, want %q", got, config.TaskRunnerMake)
+	}
+}
+
 func TestIsPublic(t *testing.T) {
 	pub := &config.ProjectConfig{Visibility: config.VisibilityPublic}
 	priv := &config.ProjectConfig{Visibility: config.VisibilityPrivate}
@@ -1,10 +1,15 @@
 package github
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
+
+	gh "github.com/google/go-github/v69/github"
+	"golang.org/x/oauth2"
 )
 
 // ValidateAuth checks that the user is authenticated with the GitHub CLI.
@@ -29,6 +34,25 @@ func TokenFromEnv() string {
 	return ""
 }
 
+// Login returns the login of the authenticated GitHub user, asking the API
+// when a token is set and the gh CLI otherwise. It fails when offline or
+// not authenticated; ctx bounds how long it may take.
+func Login(ctx context.Context) (string, error) {
+	if token := TokenFromEnv(); token != "" {
+		client := gh.NewClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})))
+		user, _, err := client.Users.Get(ctx, "")
+		if err != nil {
+			return "", err
+		}
+		return user.GetLogin(), nil
+	}
+	out, err := exec.CommandContext(ctx, "gh", "api", "user", "--jq", ".login").Output() //nolint:gosec
+	if err != nil {
+		return "", fmt.Errorf("gh api user: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 // lookupEnv is os.LookupEnv extracted for testability.
 var lookupEnv = func(key string) string {
 	v, _ := os.LookupEnv(key)
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"os"
@@ -16,10 +17,12 @@ import (
 
 // RepoOptions holds settings for repository creation.
 type RepoOptions struct {
+	Owner       string // user or organisation; empty for the authenticated user
 	Name        string
 	Description string
 	Private     bool
 	Topics      []string
+	Branch      string // branch the initial commit is pushed to
 	PushOnInit  bool
 	ProjectDir  string
 }
@@ -27,10 +30,12 @@ type RepoOptions struct {
 // OptionsFromConfig builds RepoOptions from a ProjectConfig.
 func OptionsFromConfig(cfg *config.ProjectConfig, projectDir string) RepoOptions {
 	return RepoOptions{
-		Name:        cfg.Name,
+		Owner:       cfg.GitHub.Owner,
+		Name:        cfg.RepoName(),
 		Description: cfg.Description,
 		Private:     cfg.Visibility == config.VisibilityPrivate,
 		Topics:      cfg.GitHub.Topics,
+		Branch:      cfg.DefaultBranch(),
 		PushOnInit:  cfg.GitHub.PushOnInit,
 		ProjectDir:  projectDir,
 	}
@@ -61,6 +66,14 @@ func CreateRepository(ctx context.Context, opts RepoOptions) error {
 		}
 	}
 
+	// gh repo create takes neither topics nor a default branch.
+	if args := cliEditArgs(opts); token == "" && args != nil {
+		if err := runGH(args); err != nil {
+			// Non-fatal, as with the API: the repository already exists.
+			fmt.Fprintf(os.Stderr, "warn: could not set topics or default branch: %v\n", err)
+		}
+	}
+
 	return nil
 }
 
@@ -70,19 +83,19 @@ func createViaAPI(ctx context.Context, opts RepoOptions, token string) error {
 	client := gh.NewClient(tc)
 
 	repo := &gh.Repository{
-		Name:        gh.Ptr(opts.Name),
+		Name:        gh.Ptr(sanitizeName(opts.Name)),
 		Description: gh.Ptr(opts.Description),
 		Private:     gh.Ptr(opts.Private),
 		AutoInit:    gh.Ptr(false),
 	}
 
-	created, _, err := client.Repositories.Create(ctx, "", repo)
+	created, _, err := client.Repositories.Create(ctx, orgFor(ctx, client, opts.Owner), repo)
 	if err != nil {
 		return fmt.Errorf("creating repository: %w", err)
 	}
 
 	if len(opts.Topics) > 0 {
-		if _, _, err := client.Repositories.ReplaceAllTopics(ctx, *created.Owner.Login, opts.Name, opts.Topics); err != nil {
+		if _, _, err := client.Repositories.ReplaceAllTopics(ctx, created.GetOwner().GetLogin(), created.GetName(), opts.Topics); err != nil {
 			// Non-fatal: topics are cosmetic.
 			fmt.Fprintf(os.Stderr, "warn: could not set topics: %v\n", err)
 		}
@@ -91,22 +104,68 @@ func createViaAPI(ctx context.Context, opts RepoOptions, token string) error {
 	return nil
 }
 
+// orgFor returns the organisation argument for creating a repository under
+// owner: empty when owner is the authenticated user, whose repositories the
+// API creates without one.
+func orgFor(ctx context.Context, client *gh.Client, owner string) string {
+	if owner == "" {
+		return ""
+	}
+	user, _, err := client.Users.Get(ctx, "")
+	if err == nil && strings.EqualFold(user.GetLogin(), owner) {
+		return ""
+	}
+	return owner
+}
+
 func createViaCLI(opts RepoOptions) error {
-	// Use gh CLI with explicit, sanitized arguments (no shell expansion).
+	return runGH(cliCreateArgs(opts))
+}
+
+// cliRepoName returns the owner/name argument gh takes for the repository.
+func cliRepoName(opts RepoOptions) string {
+	name := sanitizeName(opts.Name)
+	if opts.Owner != "" {
+		name = sanitizeName(opts.Owner) + "/" + name
+	}
+	return name
+}
+
+// cliCreateArgs returns the gh arguments that create the repository.
+func cliCreateArgs(opts RepoOptions) []string {
 	visibility := "--public"
 	if opts.Private {
 		visibility = "--private"
 	}
-
-	args := []string{
+	return []string{
 		"repo", "create",
-		sanitizeName(opts.Name),
+		cliRepoName(opts),
 		visibility,
 		"--description", opts.Description,
 		"--source", opts.ProjectDir,
 		"--remote", "origin",
 	}
+}
 
+// cliEditArgs returns the gh arguments that set the topics and default
+// branch of a created repository, or nil when there is nothing to set.
+// The default branch can only be set once it has been pushed.
+func cliEditArgs(opts RepoOptions) []string {
+	var flags []string
+	for _, t := range opts.Topics {
+		flags = append(flags, "--add-topic", t)
+	}
+	if opts.PushOnInit {
+		flags = append(flags, "--default-branch", cmp.Or(opts.Branch, "main"))
+	}
+	if flags == nil {
+		return nil
+	}
+	return append([]string{"repo", "edit", cliRepoName(opts)}, flags...)
+}
+
+// runGH runs gh with explicit, sanitized arguments (no shell expansion).
+func runGH(args []string) error {
 	cmd := exec.Command("gh", args...) //nolint:gosec
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -115,6 +174,7 @@ func createViaCLI(opts RepoOptions) error {
 
 func initAndPush(opts RepoOptions) error {
 	dir := opts.ProjectDir
+	branch := cmp.Or(opts.Branch, "main")
 
 	run := func(name string, args ...string) error {
 		cmd := exec.Command(name, args...) //nolint:gosec
@@ -126,7 +186,7 @@ func initAndPush(opts RepoOptions) error {
 
 	gitDir := filepath.Join(dir, ".git")
 	if _, err := os.Stat(gitDir); os.IsNotExist(err) {
-		if err := run("git", "init", "-b", "main"); err != nil {
+		if err := run("git", "init", "-b", branch); err != nil {
 			return fmt.Errorf("git init: %w", err)
 		}
 	}
@@ -139,7 +199,12 @@ func initAndPush(opts RepoOptions) error {
 		return fmt.Errorf("git commit: %w", err)
 	}
 
-	if err := run("git", "push", "-u", "origin", "main"); err != nil {
+	// A repository initialised before lazy.go ran may be on another branch.
+	if err := run("git", "branch", "-M", branch); err != nil {
+		return fmt.Errorf("git branch: %w", err)
+	}
+
+	if err := run("git", "push", "-u", "origin", branch); err != nil {
 		return fmt.Errorf("git push: %w", err)
 	}
 
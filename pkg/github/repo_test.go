@@ -0,0 +1,49 @@
+package github
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCLIArgs(t *testing.T) {
+	cases := []struct {
+		name   string
+		opts   RepoOptions
+		create []string
+		edit   []string
+	}{
+		{
+			name:   "bare",
+			opts:   RepoOptions{Name: "svc", Description: "A service", ProjectDir: "/tmp/svc"},
+			create: []string{"repo", "create", "svc", "--public", "--description", "A service", "--source", "/tmp/svc", "--remote", "origin"},
+		},
+		{
+			name:   "topics without push",
+			opts:   RepoOptions{Owner: "acme", Name: "svc", Private: true, Topics: []string{"go", "api"}, ProjectDir: "."},
+			create: []string{"repo", "create", "acme/svc", "--private", "--description", "", "--source", ".", "--remote", "origin"},
+			edit:   []string{"repo", "edit", "acme/svc", "--add-topic", "go", "--add-topic", "api"},
+		},
+		{
+			name:   "pushed to a custom branch",
+			opts:   RepoOptions{Name: "svc", Topics: []string{"go"}, Branch: "trunk", PushOnInit: true, ProjectDir: "."},
+			create: []string{"repo", "create", "svc", "--public", "--description", "", "--source", ".", "--remote", "origin"},
+			edit:   []string{"repo", "edit", "svc", "--add-topic", "go", "--default-branch", "trunk"},
+		},
+		{
+			name:   "pushed without a branch",
+			opts:   RepoOptions{Name: "svc", PushOnInit: true, ProjectDir: "."},
+			create: []string{"repo", "create", "svc", "--public", "--description", "", "--source", ".", "--remote", "origin"},
+			edit:   []string{"repo", "edit", "svc", "--default-branch", "main"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := cliCreateArgs(tc.opts); !slices.Equal(got, tc.create) {
+				t.Errorf("cliCreateArgs = %q, want %q", got, tc.create)
+			}
+			if got := cliEditArgs(tc.opts); !slices.Equal(got, tc.edit) {
+				t.Errorf("cliEditArgs = %q, want %q", got, tc.edit)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package scaffold
+
+import (
+	"cmp"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+// ciWorkflow is the GitHub Actions workflow generated for a project.
+const ciWorkflow = ".github/workflows/ci.yml"
+
+// CIJobs returns the names of the jobs in the GitHub Actions workflow
+// generated for cfg, in workflow order, or nil when none is generated.
+func CIJobs(cfg *config.ProjectConfig) ([]string, error) {
+	if !cfg.Features.GitHubActions {
+		return nil, nil
+	}
+	out, err := RenderFile(cfg, ciWorkflow)
+	if err != nil {
+		return nil, err
+	}
+	var workflow struct {
+		Jobs yaml.Node `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal([]byte(out), &workflow); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ciWorkflow, err)
+	}
+	var names []string
+	for i := 0; i+1 < len(workflow.Jobs.Content); i += 2 {
+		var job struct {
+			Name string `yaml:"name"`
+		}
+		if err := workflow.Jobs.Content[i+1].Decode(&job); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", ciWorkflow, err)
+		}
+		names = append(names, cmp.Or(job.Name, workflow.Jobs.Content[i].Value))
+	}
+	return names, nil
+}
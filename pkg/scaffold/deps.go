@@ -0,0 +1,34 @@
+package scaffold
+
+import "github.com/had-nu/lazy.go/pkg/config"
+
+// Dependencies returns the third-party modules, as "path version", that the
+// generated code imports for cfg's type, router and queue choices. The
+// generated go.mod requires them; `go mod tidy` fills in go.sum.
+func Dependencies(cfg *config.ProjectConfig) []string {
+	var deps []string
+	switch cfg.Type {
+	case config.ProjectTypeCLI, config.ProjectTypeSecurity:
+		deps = append(deps, "github.com/spf13/cobra v1.8.1") // cmd/root.go
+	}
+	if cfg.IsService() && cfg.Router() == config.RouterChi {
+		deps = append(deps, "github.com/go-chi/chi/v5 v5.1.0")
+	}
+	switch queueFor(cfg) {
+	case config.QueueNATS:
+		deps = append(deps, "github.com/nats-io/nats.go v1.34.1")
+	case config.QueueRedis:
+		deps = append(deps, "github.com/redis/go-redis/v9 v9.5.1")
+	}
+	return deps
+}
+
+// queueFor returns the queue backend the generated worker consumes, none
+// for project types without a worker.
+func queueFor(cfg *config.ProjectConfig) config.QueueBackend {
+	switch cfg.Type {
+	case config.ProjectTypeWorker, config.ProjectTypeMicroservice:
+		return cfg.Queue()
+	}
+	return config.QueueNone
+}
@@ -0,0 +1,162 @@
+package scaffold
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+// ChangeKind says how a generated file differs between two configs.
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota
+	Removed
+	Modified
+	Unchanged
+)
+
+// Symbol returns the one-character marker used when listing changes.
+func (k ChangeKind) Symbol() string {
+	switch k {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	case Modified:
+		return "~"
+	default:
+		return " "
+	}
+}
+
+// FileChange is one generated file that differs between two configs.
+type FileChange struct {
+	Path string
+	Kind ChangeKind
+}
+
+// String returns the change as "+ path", "- path" or "~ path".
+func (c FileChange) String() string {
+	return c.Kind.Symbol() + " " + c.Path
+}
+
+// Diff renders both configs and returns the generated files that would be
+// added, removed or modified by moving from before to after, sorted by path.
+// A nil before generates nothing, so every file of after is added.
+func Diff(before, after *config.ProjectConfig) ([]FileChange, error) {
+	old := map[string]string{}
+	if before != nil {
+		var err error
+		if old, err = RenderAll(before); err != nil {
+			return nil, fmt.Errorf("rendering current config: %w", err)
+		}
+	}
+	cur, err := RenderAll(after)
+	if err != nil {
+		return nil, fmt.Errorf("rendering new config: %w", err)
+	}
+
+	var changes []FileChange
+	for path, content := range cur {
+		prev, existed := old[path]
+		switch {
+		case !existed:
+			changes = append(changes, FileChange{Path: path, Kind: Added})
+		case prev != content:
+			changes = append(changes, FileChange{Path: path, Kind: Modified})
+		}
+	}
+	for path := range old {
+		if _, ok := cur[path]; !ok {
+			changes = append(changes, FileChange{Path: path, Kind: Removed})
+		}
+	}
+	slices.SortFunc(changes, func(a, b FileChange) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return changes, nil
+}
+
+// TreeEntry is a file or directory of a generated tree and how it differs
+// from an earlier tree: Unchanged, Added or Removed.
+type TreeEntry struct {
+	Path  string
+	IsDir bool
+	Kind  ChangeKind
+}
+
+// DiffTree returns the entries BuildDirectoryTree produces for before and
+// after, together with the directories their paths imply, marking those
+// only before as Removed and those only after as Added. Entries are in
+// file-browser order: depth first, directories before files, by name.
+func DiffTree(before, after *config.ProjectConfig) []TreeEntry {
+	old, cur := treePaths(before), treePaths(after)
+	var entries []TreeEntry
+	for path, isDir := range cur {
+		kind := Unchanged
+		if _, ok := old[path]; !ok {
+			kind = Added
+		}
+		entries = append(entries, TreeEntry{Path: path, IsDir: isDir, Kind: kind})
+	}
+	for path, isDir := range old {
+		if _, ok := cur[path]; !ok {
+			entries = append(entries, TreeEntry{Path: path, IsDir: isDir, Kind: Removed})
+		}
+	}
+	slices.SortFunc(entries, compareTreeEntries)
+	return entries
+}
+
+// treePaths maps every path of cfg's tree, implied directories included,
+// to whether it is a directory.
+func treePaths(cfg *config.ProjectConfig) map[string]bool {
+	paths := make(map[string]bool)
+	for _, e := range BuildDirectoryTree(cfg) {
+		paths[e.Path] = paths[e.Path] || e.IsDir
+		for dir := path.Dir(e.Path); dir != "."; dir = path.Dir(dir) {
+			paths[dir] = true
+		}
+	}
+	return paths
+}
+
+// compareTreeEntries orders entries as a file browser lists them.
+func compareTreeEntries(a, b TreeEntry) int {
+	ap, bp := strings.Split(a.Path, "/"), strings.Split(b.Path, "/")
+	for i := 0; i < len(ap) && i < len(bp); i++ {
+		if ap[i] == bp[i] {
+			continue
+		}
+		// A component is a directory when more follow it, or it is one.
+		aDir := i < len(ap)-1 || a.IsDir
+		bDir := i < len(bp)-1 || b.IsDir
+		if aDir != bDir {
+			if aDir {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(ap[i], bp[i])
+	}
+	return len(ap) - len(bp)
+}
+
+// RenderFile renders the generated file at path for cfg. Files without a
+// template are empty.
+func RenderFile(cfg *config.ProjectConfig, path string) (string, error) {
+	for _, e := range BuildDirectoryTree(cfg) {
+		if e.Path != path || e.IsDir {
+			continue
+		}
+		if e.Template == "" {
+			return "", nil
+		}
+		return RenderTemplate(e.Template, e.Data)
+	}
+	return "", fmt.Errorf("%s is not generated for this configuration", path)
+}
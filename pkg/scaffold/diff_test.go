@@ -0,0 +1,105 @@
+package scaffold_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+	"github.com/had-nu/lazy.go/pkg/scaffold"
+)
+
+func TestDiff(t *testing.T) {
+	before := cfg(config.ProjectTypeAPI)
+	after := cfg(config.ProjectTypeAPI)
+	after.Features.Docker = true
+	after.Description = "changed"
+
+	changes, err := scaffold.Diff(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]scaffold.ChangeKind)
+	for _, c := range changes {
+		got[c.Path] = c.Kind
+	}
+	for path, want := range map[string]scaffold.ChangeKind{
+		"Dockerfile": scaffold.Added,
+		"README.md":  scaffold.Modified,
+	} {
+		if kind, ok := got[path]; !ok || kind != want {
+			t.Errorf("%s: got %v (listed %v), want %v", path, kind, ok, want)
+		}
+	}
+	if _, ok := got["go.mod"]; ok {
+		t.Error("unchanged go.mod listed")
+	}
+
+	changes, err = scaffold.Diff(after, before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range changes {
+		if c.Path == "Dockerfile" && c.Kind != scaffold.Removed {
+			t.Errorf("Dockerfile: got %v, want removed", c.Kind)
+		}
+	}
+
+	changes, err = scaffold.Diff(nil, before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range changes {
+		if c.Kind != scaffold.Added {
+			t.Errorf("%s: got %v from nothing, want added", c.Path, c.Kind)
+		}
+	}
+	if len(changes) == 0 {
+		t.Error("no files added from nothing")
+	}
+}
+
+func TestDiffTree(t *testing.T) {
+	before := cfg(config.ProjectTypeLibrary)
+	after := cfg(config.ProjectTypeLibrary)
+	after.Features.GitHubActions = true
+
+	entries := scaffold.DiffTree(before, after)
+	kinds := make(map[string]scaffold.ChangeKind)
+	var order []string
+	for _, e := range entries {
+		kinds[e.Path] = e.Kind
+		order = append(order, e.Path)
+	}
+	for path, want := range map[string]scaffold.ChangeKind{
+		".github":                  scaffold.Added, // implied by the workflow
+		".github/workflows/ci.yml": scaffold.Added,
+		"pkg":                      scaffold.Unchanged,
+		"go.mod":                   scaffold.Unchanged,
+	} {
+		if got, ok := kinds[path]; !ok || got != want {
+			t.Errorf("%s: got %v (listed %v), want %v", path, got, ok, want)
+		}
+	}
+	// Directories come before files.
+	if order[0] != ".github" {
+		t.Errorf("first entry = %s, want .github in %v", order[0], order)
+	}
+
+	if got := scaffold.DiffTree(after, before); got[0].Kind != scaffold.Removed {
+		t.Errorf("reversed: %s is %v, want removed", got[0].Path, got[0].Kind)
+	}
+}
+
+func TestRenderFile(t *testing.T) {
+	c := cfg(config.ProjectTypeCLI)
+	content, err := scaffold.RenderFile(c, "go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(content, "module github.com/user/myapp") {
+		t.Errorf("go.mod = %q", content)
+	}
+	if _, err := scaffold.RenderFile(c, "Dockerfile"); err == nil {
+		t.Error("rendered a file the config does not generate")
+	}
+}
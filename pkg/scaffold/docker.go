@@ -0,0 +1,212 @@
+package scaffold
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+// GoVersion is the Go release generated projects target: the go directive
+// in go.mod, the builder image, CI and the linter.
+const GoVersion = "1.22"
+
+// Base images used by the generated Dockerfile.
+const (
+	builderImage    = "golang:" + GoVersion + "-alpine"
+	distrolessImage = "gcr.io/distroless/static:nonroot"
+	scratchImage    = "scratch"
+)
+
+// DockerImages holds the image references rendered into the Dockerfile.
+type DockerImages struct {
+	Base    config.DockerBase
+	Builder string
+	Runtime string
+	Pinned  bool // every pullable reference carries a digest
+}
+
+// ImageDigests maps image references to the manifest digests they are
+// pinned to.
+type ImageDigests map[string]string
+
+// ImagesFor returns the base images for a project. Security-critical
+// projects get references pinned by the digests in digests; an image
+// missing from it is left unpinned, and the Dockerfile says so.
+func ImagesFor(cfg *config.ProjectConfig, digests ImageDigests) DockerImages {
+	imgs := DockerImages{
+		Base:    cfg.DockerBase(),
+		Builder: builderImage,
+		Runtime: distrolessImage,
+	}
+	if imgs.Base == config.DockerBaseScratch {
+		imgs.Runtime = scratchImage
+	}
+	if cfg.Criticality != config.CriticalitySecurity {
+		return imgs
+	}
+
+	imgs.Pinned = true
+	for _, ref := range []*string{&imgs.Builder, &imgs.Runtime} {
+		if *ref == scratchImage {
+			continue
+		}
+		digest, ok := digests[*ref]
+		if !ok {
+			imgs.Pinned = false
+			continue
+		}
+		*ref += "@" + digest
+	}
+	return imgs
+}
+
+// ResolveDigests asks the registries for the digests of the images
+// ImagesFor pins for cfg. Projects that pin nothing need no network and get
+// nil. Images that cannot be resolved are left out, and the first failure
+// is returned alongside the digests that were found.
+func ResolveDigests(ctx context.Context, cfg *config.ProjectConfig) (ImageDigests, error) {
+	if cfg.Criticality != config.CriticalitySecurity || !cfg.Features.Docker {
+		return nil, nil
+	}
+	imgs := ImagesFor(cfg, nil)
+	digests := ImageDigests{}
+	var firstErr error
+	for _, ref := range []string{imgs.Builder, imgs.Runtime} {
+		if ref == scratchImage {
+			continue
+		}
+		digest, err := resolveDigest(ctx, ref)
+		if err != nil {
+			firstErr = cmp.Or(firstErr, err)
+			continue
+		}
+		digests[ref] = digest
+	}
+	return digests, firstErr
+}
+
+// resolveDigest returns the manifest digest of an image reference.
+// Extracted as a variable so tests can substitute it without network access.
+var resolveDigest = registryDigest
+
+// manifestTypes lists the manifest media types accepted from registries.
+var manifestTypes = strings.Join([]string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+// registryDigest queries the image registry (anonymously) for the digest of ref.
+func registryDigest(ctx context.Context, ref string) (string, error) {
+	registry, repo, tag := splitImageRef(ref)
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repo, tag)
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := headManifest(ctx, url, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := anonymousToken(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", err
+		}
+		if resp, err = headManifest(ctx, url, token); err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned %s for %s", resp.Status, ref)
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if !strings.HasPrefix(digest, "sha256:") {
+		return "", fmt.Errorf("registry returned no digest for %s", ref)
+	}
+	return digest, nil
+}
+
+func headManifest(ctx context.Context, url, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", manifestTypes)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("querying registry: %w", err)
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// anonymousToken follows a Bearer challenge to obtain a pull token.
+func anonymousToken(ctx context.Context, challenge string) (string, error) {
+	params := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok {
+			params[k] = strings.Trim(v, `"`)
+		}
+	}
+	if params["realm"] == "" {
+		return "", fmt.Errorf("unsupported registry auth challenge: %q", challenge)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, params["realm"], nil)
+	if err != nil {
+		return "", err
+	}
+	q := req.URL.Query()
+	q.Set("service", params["service"])
+	q.Set("scope", params["scope"])
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("requesting registry token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding registry token: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// splitImageRef splits "host/repo:tag" into its parts, applying Docker Hub
+// defaults for short references such as "golang:1.22-alpine". The first
+// path component is a registry host when it has a '.' or a port, or is
+// localhost, so "localhost:5000/app:1.0" is repo app on localhost:5000.
+func splitImageRef(ref string) (registry, repo, tag string) {
+	registry, repo = "registry-1.docker.io", ref
+	if host, rest, ok := strings.Cut(ref, "/"); ok && (strings.ContainsAny(host, ".:") || host == "localhost") {
+		registry, repo = host, rest
+	}
+	tag = "latest"
+	if i := strings.LastIndex(repo, ":"); i >= 0 {
+		repo, tag = repo[:i], repo[i+1:]
+	}
+	if registry == "registry-1.docker.io" && !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+	return registry, repo, tag
+}
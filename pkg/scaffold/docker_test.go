@@ -0,0 +1,129 @@
+package scaffold
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+func TestSplitImageRef(t *testing.T) {
+	cases := []struct {
+		ref, registry, repo, tag string
+	}{
+		{"golang:1.22-alpine", "registry-1.docker.io", "library/golang", "1.22-alpine"},
+		{"gcr.io/distroless/static:nonroot", "gcr.io", "distroless/static", "nonroot"},
+		{"user/image", "registry-1.docker.io", "user/image", "latest"},
+		{"localhost:5000/app:1.0", "localhost:5000", "app", "1.0"},
+		{"registry.example.com:8443/team/app", "registry.example.com:8443", "team/app", "latest"},
+		{"localhost/app:dev", "localhost", "app", "dev"},
+	}
+	for _, c := range cases {
+		registry, repo, tag := splitImageRef(c.ref)
+		if registry != c.registry || repo != c.repo || tag != c.tag {
+			t.Errorf("splitImageRef(%q) = %q, %q, %q", c.ref, registry, repo, tag)
+		}
+	}
+}
+
+func TestImagesFor_PinsSecurityCritical(t *testing.T) {
+	cfg := &config.ProjectConfig{Criticality: config.CriticalitySecurity}
+	imgs := ImagesFor(cfg, ImageDigests{builderImage: "sha256:abc", distrolessImage: "sha256:def"})
+	if !imgs.Pinned {
+		t.Fatal("expected pinned images")
+	}
+	if imgs.Builder != builderImage+"@sha256:abc" || imgs.Runtime != distrolessImage+"@sha256:def" {
+		t.Errorf("expected references pinned by digest: %+v", imgs)
+	}
+
+	if imgs := ImagesFor(cfg, ImageDigests{builderImage: "sha256:abc"}); imgs.Pinned {
+		t.Errorf("expected unpinned images with a digest missing: %+v", imgs)
+	}
+}
+
+func TestImagesFor_BuilderMatchesGoVersion(t *testing.T) {
+	imgs := ImagesFor(&config.ProjectConfig{}, nil)
+	if want := "golang:" + GoVersion + "-alpine"; imgs.Builder != want {
+		t.Errorf("Builder = %q, want %q", imgs.Builder, want)
+	}
+}
+
+func TestImagesFor_ScratchBase(t *testing.T) {
+	cfg := &config.ProjectConfig{Docker: config.DockerConfig{Base: config.DockerBaseScratch}}
+	if imgs := ImagesFor(cfg, nil); imgs.Runtime != scratchImage {
+		t.Errorf("Runtime = %q, want %q", imgs.Runtime, scratchImage)
+	}
+}
+
+func TestResolveDigests(t *testing.T) {
+	var asked []string
+	stubDigest(t, func(_ context.Context, ref string) (string, error) {
+		asked = append(asked, ref)
+		if ref == distrolessImage {
+			return "", errors.New("offline")
+		}
+		return "sha256:abc", nil
+	})
+
+	cfg := &config.ProjectConfig{Criticality: config.CriticalityProduction}
+	cfg.Features.Docker = true
+	if digests, err := ResolveDigests(context.Background(), cfg); digests != nil || err != nil || len(asked) > 0 {
+		t.Errorf("resolved digests for a project that pins nothing: %v, %v, asked %v", digests, err, asked)
+	}
+
+	cfg.Criticality = config.CriticalitySecurity
+	digests, err := ResolveDigests(context.Background(), cfg)
+	if err == nil {
+		t.Error("expected the failed lookup to be reported")
+	}
+	if digests[builderImage] != "sha256:abc" || len(digests) != 1 {
+		t.Errorf("digests = %v, want only the builder's", digests)
+	}
+}
+
+// stubDigest replaces the registry lookup.
+func stubDigest(t *testing.T, fn func(context.Context, string) (string, error)) {
+	t.Helper()
+	orig := resolveDigest
+	resolveDigest = fn
+	t.Cleanup(func() { resolveDigest = orig })
+}
+
+func TestRendering_PinsOnlyGivenDigests(t *testing.T) {
+	stubDigest(t, func(_ context.Context, ref string) (string, error) {
+		t.Errorf("rendering looked up %s in the registry", ref)
+		return "", errors.New("offline")
+	})
+	cfg := &config.ProjectConfig{
+		Name:        "svc",
+		ModulePath:  "github.com/acme/svc",
+		Type:        config.ProjectTypeAPI,
+		Criticality: config.CriticalitySecurity,
+		Features:    config.Features{Docker: true},
+	}
+
+	files, err := RenderAll(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(files["Dockerfile"], "FROM "+builderImage+" AS builder") {
+		t.Errorf("unpinned Dockerfile:\n%s", files["Dockerfile"])
+	}
+
+	dir := t.TempDir()
+	digests := ImageDigests{builderImage: "sha256:abc", distrolessImage: "sha256:def"}
+	if err := New(cfg, dir).WithDigests(digests).Generate(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "Dockerfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "FROM "+builderImage+"@sha256:abc AS builder") {
+		t.Errorf("Dockerfile not pinned:\n%s", data)
+	}
+}
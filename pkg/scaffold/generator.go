@@ -20,8 +20,9 @@ type DirEntry struct {
 
 // Generator orchestrates the full project scaffolding.
 type Generator struct {
-	cfg    *config.ProjectConfig
-	outDir string
+	cfg     *config.ProjectConfig
+	outDir  string
+	digests ImageDigests
 }
 
 // New creates a new Generator.
@@ -29,13 +30,20 @@ func New(cfg *config.ProjectConfig, outDir string) *Generator {
 	return &Generator{cfg: cfg, outDir: outDir}
 }
 
+// WithDigests returns g pinning the Dockerfile's base images by digests,
+// as resolved by ResolveDigests.
+func (g *Generator) WithDigests(digests ImageDigests) *Generator {
+	g.digests = digests
+	return g
+}
+
 // Generate runs the full scaffold pipeline.
 func (g *Generator) Generate() error {
 	if err := os.MkdirAll(g.outDir, 0o755); err != nil {
 		return fmt.Errorf("creating output directory: %w", err)
 	}
 
-	entries := BuildDirectoryTree(g.cfg)
+	entries := buildDirectoryTree(g.cfg, g.digests)
 
 	for _, e := range entries {
 		fullPath := filepath.Join(g.outDir, e.Path)
@@ -79,16 +87,50 @@ type templateData struct {
 	Year        int
 	LibName     string
 	ServiceName string
+	MainPackage string         // import path of the main package, empty for libraries
+	VersionVar  string         // symbol set via -ldflags "-X" at build time
+	Vars        map[string]any // user variables from lazygo.yml, see ProjectConfig.TemplateVars
+	GoVersion   string
+	Images      DockerImages
 }
 
 // commonData builds the shared template data struct.
-func commonData(cfg *config.ProjectConfig) templateData {
+func commonData(cfg *config.ProjectConfig, digests ImageDigests) templateData {
 	libName := strings.ToLower(strings.ReplaceAll(cfg.Name, "-", ""))
-	return templateData{
+	data := templateData{
 		Config:      cfg,
 		Year:        2026,
 		LibName:     libName,
 		ServiceName: cfg.Name,
+		MainPackage: mainPackage(cfg.Type),
+		Vars:        cfg.TemplateVars(),
+		GoVersion:   GoVersion,
+		Images:      ImagesFor(cfg, digests),
+	}
+	switch cfg.Type {
+	case config.ProjectTypeCLI, config.ProjectTypeSecurity:
+		data.VersionVar = cfg.ModulePath + "/cmd.version"
+	case config.ProjectTypeLibrary:
+		// Libraries have no binary to stamp.
+	default:
+		data.VersionVar = "main.version"
+	}
+	return data
+}
+
+// mainPackage returns the relative path of the main package for a project type.
+func mainPackage(t config.ProjectType) string {
+	switch t {
+	case config.ProjectTypeAPI:
+		return "./cmd/server"
+	case config.ProjectTypeMicroservice:
+		return "./cmd/service"
+	case config.ProjectTypeWorker:
+		return "./cmd/worker"
+	case config.ProjectTypeLibrary:
+		return ""
+	default:
+		return "."
 	}
 }
 
@@ -117,4 +159,7 @@ var funcMap = template.FuncMap{
 	"lower":   strings.ToLower,
 	"replace": strings.ReplaceAll,
 	"join":    strings.Join,
+
+	"dependencies": Dependencies,
+	"queue":        queueFor,
 }
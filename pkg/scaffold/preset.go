@@ -0,0 +1,138 @@
+package scaffold
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+//go:embed presets/*.yml
+var presetFS embed.FS
+
+// Preset is a named, partial lazygo.yml describing a common project shape.
+// It sets everything except the project name and module path.
+type Preset struct {
+	Name    string // file name without extension
+	Summary string // first comment line of the file
+	Path    string // file on disk; empty for built-in presets
+	Data    []byte // file contents
+}
+
+// Builtin reports whether the preset ships with lazy.go.
+func (p Preset) Builtin() bool {
+	return p.Path == ""
+}
+
+// Source names where the preset was loaded from.
+func (p Preset) Source() string {
+	if p.Builtin() {
+		return "built-in"
+	}
+	return p.Path
+}
+
+// Apply overlays the preset onto cfg. The project name and module path
+// are never taken from a preset.
+func (p Preset) Apply(cfg *config.ProjectConfig) error {
+	name, module := cfg.Name, cfg.ModulePath
+	var err error
+	if p.Builtin() {
+		err = config.ApplyYAML(cfg, "preset "+p.Name, p.Data)
+	} else {
+		err = config.ApplyFile(cfg, p.Path)
+	}
+	cfg.Name, cfg.ModulePath = name, module
+	return err
+}
+
+// PresetsDir returns the directory user presets are loaded from,
+// presets/ in config.UserDir.
+func PresetsDir() (string, error) {
+	dir, err := config.UserDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "presets"), nil
+}
+
+// Presets returns the built-in presets followed by the *.yml and *.yaml
+// files in userDir, sorted by name. A user preset replaces a built-in one
+// of the same name. A missing or empty userDir yields only built-ins.
+func Presets(userDir string) ([]Preset, error) {
+	byName := make(map[string]Preset)
+
+	builtins, err := presetFS.ReadDir("presets")
+	if err != nil {
+		return nil, fmt.Errorf("reading built-in presets: %w", err)
+	}
+	for _, e := range builtins {
+		data, err := presetFS.ReadFile(path.Join("presets", e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading built-in preset %s: %w", e.Name(), err)
+		}
+		p := newPreset(e.Name(), data)
+		byName[p.Name] = p
+	}
+
+	if userDir != "" {
+		entries, err := os.ReadDir(userDir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("reading presets directory: %w", err)
+		}
+		for _, e := range entries {
+			ext := filepath.Ext(e.Name())
+			if e.IsDir() || (ext != ".yml" && ext != ".yaml") {
+				continue
+			}
+			file := filepath.Join(userDir, e.Name())
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("reading preset: %w", err)
+			}
+			p := newPreset(e.Name(), data)
+			p.Path = file
+			byName[p.Name] = p
+		}
+	}
+
+	presets := make([]Preset, 0, len(byName))
+	for _, p := range byName {
+		presets = append(presets, p)
+	}
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	return presets, nil
+}
+
+// FindPreset returns the preset called name from Presets(userDir).
+func FindPreset(userDir, name string) (Preset, error) {
+	presets, err := Presets(userDir)
+	if err != nil {
+		return Preset{}, err
+	}
+	names := make([]string, len(presets))
+	for i, p := range presets {
+		if p.Name == name {
+			return p, nil
+		}
+		names[i] = p.Name
+	}
+	return Preset{}, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+func newPreset(file string, data []byte) Preset {
+	p := Preset{
+		Name: strings.TrimSuffix(file, filepath.Ext(file)),
+		Data: data,
+	}
+	first, _, _ := strings.Cut(string(data), "\n")
+	if summary, ok := strings.CutPrefix(first, "#"); ok {
+		p.Summary = strings.TrimSpace(summary)
+	}
+	return p
+}
@@ -0,0 +1,79 @@
+package scaffold_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+	"github.com/had-nu/lazy.go/pkg/scaffold"
+)
+
+func TestPresets_BuiltinsAreClean(t *testing.T) {
+	presets, err := scaffold.Presets("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"internal-api-prod", "oss-library", "security-scanner"}
+	if len(presets) != len(want) {
+		t.Fatalf("got %d built-in presets, want %d", len(presets), len(want))
+	}
+	for i, p := range presets {
+		if p.Name != want[i] || !p.Builtin() || p.Summary == "" {
+			t.Errorf("preset %d = %+v, want built-in %q with a summary", i, p, want[i])
+		}
+		cfg := &config.ProjectConfig{Name: "svc", ModulePath: "github.com/acme/svc"}
+		if err := p.Apply(cfg); err != nil {
+			t.Fatalf("%s: %v", p.Name, err)
+		}
+		if diags := config.Check(cfg); len(diags) > 0 {
+			t.Errorf("%s produces diagnostics: %v", p.Name, diags)
+		}
+	}
+}
+
+func TestPresets_UserOverridesBuiltin(t *testing.T) {
+	dir := t.TempDir()
+	user := "# Our flavour of library.\nproject:\n    type: library\n    license: mit\n"
+	if err := os.WriteFile(filepath.Join(dir, "oss-library.yml"), []byte(user), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := scaffold.FindPreset(dir, "oss-library")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Builtin() || p.Summary != "Our flavour of library." {
+		t.Errorf("user preset not preferred: %+v", p)
+	}
+
+	cfg := &config.ProjectConfig{Name: "lib", ModulePath: "example.com/lib", License: config.LicenseApache2}
+	if err := p.Apply(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.License != config.LicenseMIT || cfg.Name != "lib" {
+		t.Errorf("Apply = %+v", cfg)
+	}
+}
+
+func TestPreset_ApplyKeepsIdentity(t *testing.T) {
+	p := scaffold.Preset{Name: "sneaky", Data: []byte("project:\n    name: other\n    module_path: example.com/other\n")}
+	cfg := &config.ProjectConfig{Name: "mine", ModulePath: "example.com/mine"}
+	if err := p.Apply(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "mine" || cfg.ModulePath != "example.com/mine" {
+		t.Errorf("preset overwrote identity: %+v", cfg)
+	}
+}
+
+func TestFindPreset_Unknown(t *testing.T) {
+	_, err := scaffold.FindPreset("", "nope")
+	if err == nil || !strings.Contains(err.Error(), "oss-library") {
+		t.Errorf("want error listing available presets, got %v", err)
+	}
+}
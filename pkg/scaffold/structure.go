@@ -4,10 +4,19 @@ import (
 	"github.com/had-nu/lazy.go/pkg/config"
 )
 
+// codeownersVar is the template variable that, when set, generates a
+// CODEOWNERS file assigning the whole repository to its value.
+const codeownersVar = "codeowners"
+
 // BuildDirectoryTree returns the list of directories and files to create
 // for a given project configuration. No unnecessary empty directories.
+// Base images are left unpinned; Generator.WithDigests pins them.
 func BuildDirectoryTree(cfg *config.ProjectConfig) []DirEntry {
-	data := commonData(cfg)
+	return buildDirectoryTree(cfg, nil)
+}
+
+func buildDirectoryTree(cfg *config.ProjectConfig, digests ImageDigests) []DirEntry {
+	data := commonData(cfg, digests)
 	var entries []DirEntry
 
 	add := func(path, tmpl string, isDir bool) {
@@ -36,9 +45,18 @@ func BuildDirectoryTree(cfg *config.ProjectConfig) []DirEntry {
 		file(".golangci.yml", "golangci.tmpl")
 	}
 
+	if cfg.Features.Hooks {
+		if cfg.HookManager() == config.HookManagerLefthook {
+			file("lefthook.yml", "lefthook.tmpl")
+		} else {
+			file(".pre-commit-config.yaml", "precommit.tmpl")
+		}
+		file(".commitlintrc.yml", "commitlint.tmpl")
+	}
+
 	if cfg.Features.GitHubActions {
 		dir(".github/workflows")
-		file(".github/workflows/ci.yml", "workflow.tmpl")
+		file(ciWorkflow, "workflow.tmpl")
 	}
 
 	if cfg.GitHub.Enabled {
@@ -46,6 +64,11 @@ func BuildDirectoryTree(cfg *config.ProjectConfig) []DirEntry {
 		file(".github/PULL_REQUEST_TEMPLATE.md", "pr_template.tmpl")
 	}
 
+	if owners, ok := data.Vars[codeownersVar]; ok && owners != "" {
+		dir(".github")
+		file(".github/CODEOWNERS", "codeowners.tmpl")
+	}
+
 	if cfg.Features.Dependabot {
 		dir(".github")
 		file(".github/dependabot.yml", "dependabot.tmpl")
@@ -67,7 +90,8 @@ func BuildDirectoryTree(cfg *config.ProjectConfig) []DirEntry {
 		buildWorkerStructure(cfg, &entries, data)
 	}
 
-	if cfg.Features.Docker {
+	// Libraries have no binary to containerise.
+	if cfg.Features.Docker && cfg.Type != config.ProjectTypeLibrary {
 		entries = append(entries, DirEntry{Path: "Dockerfile", IsDir: false, Template: "dockerfile.tmpl", Data: data})
 		entries = append(entries, DirEntry{Path: ".dockerignore", IsDir: false, Template: "dockerignore.tmpl", Data: data})
 	}
@@ -83,8 +107,12 @@ func buildLibraryStructure(cfg *config.ProjectConfig, entries *[]DirEntry, data
 	add(pkg+"/"+data.LibName+".go", "lib.tmpl", false)
 	add(pkg+"/"+data.LibName+"_test.go", "lib_test.tmpl", false)
 	if cfg.Features.Tests {
-		add("Makefile", "makefile.tmpl", false)
+		add(pkg+"/"+data.LibName+"_fuzz_test.go", "lib_fuzz_test.tmpl", false)
+		add(pkg+"/"+data.LibName+"_bench_test.go", "lib_bench_test.tmpl", false)
 	}
+	// CI and the README run the tests through the task runner.
+	runnerPath, runnerTmpl := taskRunnerFile(cfg)
+	add(runnerPath, runnerTmpl, false)
 }
 
 func buildCLIStructure(cfg *config.ProjectConfig, entries *[]DirEntry, data templateData) {
@@ -95,7 +123,12 @@ func buildCLIStructure(cfg *config.ProjectConfig, entries *[]DirEntry, data temp
 	add("cmd/root.go", "cmd_root.tmpl", false)
 	add("internal/app/app.go", "internal_app.tmpl", false)
 	add("internal/config/config.go", "internal_config.tmpl", false)
-	add("Makefile", "makefile.tmpl", false)
+	if cfg.Features.Tests {
+		add("cmd/root_test.go", "cmd_root_test.tmpl", false)
+		add("internal/testutil/testutil.go", "testutil.tmpl", false)
+	}
+	runnerPath, runnerTmpl := taskRunnerFile(cfg)
+	add(runnerPath, runnerTmpl, false)
 }
 
 func buildAPIStructure(cfg *config.ProjectConfig, entries *[]DirEntry, data templateData) {
@@ -109,7 +142,13 @@ func buildAPIStructure(cfg *config.ProjectConfig, entries *[]DirEntry, data temp
 	add("internal/middleware/middleware.go", "middleware.tmpl", false)
 	add("internal/config/config.go", "internal_config.tmpl", false)
 	add("api/openapi.yaml", "openapi.tmpl", false)
-	add("Makefile", "makefile.tmpl", false)
+	if cfg.Features.Tests {
+		add("internal/handler/handler_test.go", "handler_test.tmpl", false)
+		add("internal/handler/handler_bench_test.go", "handler_bench_test.tmpl", false)
+		add("internal/testutil/testutil.go", "testutil.tmpl", false)
+	}
+	runnerPath, runnerTmpl := taskRunnerFile(cfg)
+	add(runnerPath, runnerTmpl, false)
 }
 
 func buildMicroserviceStructure(cfg *config.ProjectConfig, entries *[]DirEntry, data templateData) {
@@ -123,7 +162,17 @@ func buildMicroserviceStructure(cfg *config.ProjectConfig, entries *[]DirEntry,
 	add("internal/middleware/middleware.go", "middleware.tmpl", false)
 	add("internal/config/config.go", "internal_config.tmpl", false)
 	add("internal/worker/worker.go", "worker.tmpl", false)
-	add("Makefile", "makefile.tmpl", false)
+	if queueFor(cfg) != config.QueueNone {
+		add("internal/queue/queue.go", "queue.tmpl", false)
+	}
+	if cfg.Features.Tests {
+		add("internal/handler/handler_test.go", "handler_test.tmpl", false)
+		add("internal/handler/handler_bench_test.go", "handler_bench_test.tmpl", false)
+		add("internal/worker/worker_test.go", "worker_test.tmpl", false)
+		add("internal/testutil/testutil.go", "testutil.tmpl", false)
+	}
+	runnerPath, runnerTmpl := taskRunnerFile(cfg)
+	add(runnerPath, runnerTmpl, false)
 }
 
 func buildSecurityToolStructure(cfg *config.ProjectConfig, entries *[]DirEntry, data templateData) {
@@ -136,15 +185,43 @@ func buildSecurityToolStructure(cfg *config.ProjectConfig, entries *[]DirEntry,
 	add("internal/report/report.go", "report.tmpl", false)
 	add("internal/config/config.go", "internal_config.tmpl", false)
 	add("pkg/"+data.LibName+"/"+data.LibName+".go", "lib.tmpl", false)
-	add("Makefile", "makefile.tmpl", false)
+	if cfg.Features.Tests {
+		add("cmd/root_test.go", "cmd_root_test.tmpl", false)
+		add("pkg/"+data.LibName+"/"+data.LibName+"_fuzz_test.go", "lib_fuzz_test.tmpl", false)
+		add("internal/testutil/testutil.go", "testutil.tmpl", false)
+	}
+	runnerPath, runnerTmpl := taskRunnerFile(cfg)
+	add(runnerPath, runnerTmpl, false)
 }
 
-func buildWorkerStructure(_ *config.ProjectConfig, entries *[]DirEntry, data templateData) {
+func buildWorkerStructure(cfg *config.ProjectConfig, entries *[]DirEntry, data templateData) {
 	add := func(path, tmpl string, isDir bool) {
 		*entries = append(*entries, DirEntry{Path: path, IsDir: isDir, Template: tmpl, Data: data})
 	}
 	add("cmd/worker/main.go", "main_api.tmpl", false)
+	add("internal/handler/handler.go", "handler.tmpl", false) // health endpoint served by cmd/worker
 	add("internal/worker/worker.go", "worker.tmpl", false)
 	add("internal/config/config.go", "internal_config.tmpl", false)
-	add("Makefile", "makefile.tmpl", false)
+	if queueFor(cfg) != config.QueueNone {
+		add("internal/queue/queue.go", "queue.tmpl", false)
+	}
+	if cfg.Features.Tests {
+		add("internal/worker/worker_test.go", "worker_test.tmpl", false)
+		add("internal/testutil/testutil.go", "testutil.tmpl", false)
+	}
+	runnerPath, runnerTmpl := taskRunnerFile(cfg)
+	add(runnerPath, runnerTmpl, false)
+}
+
+// taskRunnerFile returns the path and template of the task runner file
+// selected in the configuration.
+func taskRunnerFile(cfg *config.ProjectConfig) (path, tmpl string) {
+	switch cfg.Runner() {
+	case config.TaskRunnerTask:
+		return "Taskfile.yml", "taskfile.tmpl"
+	case config.TaskRunnerJust:
+		return "justfile", "justfile.tmpl"
+	default:
+		return "Makefile", "makefile.tmpl"
+	}
 }
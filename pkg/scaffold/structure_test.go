@@ -1,6 +1,7 @@
 package scaffold_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/had-nu/lazy.go/pkg/config"
@@ -23,10 +24,38 @@ func TestBuildDirectoryTree_Library(t *testing.T) {
 	assertContainsPath(t, entries, ".gitignore")
 	assertContainsPath(t, entries, "pkg/myapp/myapp.go")
 	assertContainsPath(t, entries, "pkg/myapp/myapp_test.go")
+	// CI runs the tests through the task runner, with or without the
+	// tests feature.
+	assertContainsPath(t, entries, "Makefile")
 	// Libraries should NOT have cmd/
 	assertNotContainsPrefix(t, entries, "cmd/")
 }
 
+func TestRenderAll_GoModRequiresImports(t *testing.T) {
+	cases := map[config.ProjectType]string{
+		config.ProjectTypeCLI:      "require (\n\tgithub.com/spf13/cobra v1.8.1\n)\n",
+		config.ProjectTypeSecurity: "require (\n\tgithub.com/spf13/cobra v1.8.1\n)\n",
+	}
+	for typ, want := range cases {
+		files, err := scaffold.RenderAll(cfg(typ))
+		if err != nil {
+			t.Fatalf("RenderAll(%s): %v", typ, err)
+		}
+		if !strings.Contains(files["go.mod"], want) {
+			t.Errorf("%s go.mod does not require cobra:\n%s", typ, files["go.mod"])
+		}
+	}
+	for _, typ := range []config.ProjectType{config.ProjectTypeLibrary, config.ProjectTypeAPI} {
+		files, err := scaffold.RenderAll(cfg(typ))
+		if err != nil {
+			t.Fatalf("RenderAll(%s): %v", typ, err)
+		}
+		if strings.Contains(files["go.mod"], "require") {
+			t.Errorf("%s go.mod requires modules it does not import:\n%s", typ, files["go.mod"])
+		}
+	}
+}
+
 func TestBuildDirectoryTree_CLI(t *testing.T) {
 	entries := scaffold.BuildDirectoryTree(cfg(config.ProjectTypeCLI))
 	assertContainsPath(t, entries, "main.go")
@@ -51,6 +80,28 @@ func TestBuildDirectoryTree_SecurityTool(t *testing.T) {
 	assertContainsPath(t, entries, "internal/report/report.go")
 }
 
+func TestBuildDirectoryTree_Tests(t *testing.T) {
+	cases := map[config.ProjectType][]string{
+		config.ProjectTypeLibrary:      {"pkg/myapp/myapp_fuzz_test.go", "pkg/myapp/myapp_bench_test.go"},
+		config.ProjectTypeCLI:          {"cmd/root_test.go", "internal/testutil/testutil.go"},
+		config.ProjectTypeAPI:          {"internal/handler/handler_test.go", "internal/handler/handler_bench_test.go", "internal/testutil/testutil.go"},
+		config.ProjectTypeMicroservice: {"internal/handler/handler_test.go", "internal/worker/worker_test.go"},
+		config.ProjectTypeWorker:       {"internal/worker/worker_test.go", "internal/testutil/testutil.go"},
+		config.ProjectTypeSecurity:     {"cmd/root_test.go", "pkg/myapp/myapp_fuzz_test.go"},
+	}
+	for typ, paths := range cases {
+		c := cfg(typ)
+		c.Features.Tests = true
+		entries := scaffold.BuildDirectoryTree(c)
+		for _, p := range paths {
+			assertContainsPath(t, entries, p)
+		}
+
+		c.Features.Tests = false
+		assertNotContainsPrefix(t, scaffold.BuildDirectoryTree(c), "internal/testutil/")
+	}
+}
+
 func TestBuildDirectoryTree_Docker(t *testing.T) {
 	c := cfg(config.ProjectTypeCLI)
 	c.Features.Docker = true
@@ -59,6 +110,13 @@ func TestBuildDirectoryTree_Docker(t *testing.T) {
 	assertContainsPath(t, entries, ".dockerignore")
 }
 
+func TestBuildDirectoryTree_LibraryHasNoDockerfile(t *testing.T) {
+	c := cfg(config.ProjectTypeLibrary)
+	c.Features.Docker = true
+	entries := scaffold.BuildDirectoryTree(c)
+	assertNotContainsPrefix(t, entries, "Dockerfile")
+}
+
 func TestBuildDirectoryTree_GolangCI(t *testing.T) {
 	c := cfg(config.ProjectTypeCLI)
 	c.Features.Linting = true
@@ -73,6 +131,33 @@ func TestBuildDirectoryTree_GitHubActions(t *testing.T) {
 	assertContainsPath(t, entries, ".github/workflows/ci.yml")
 }
 
+func TestBuildDirectoryTree_Hooks(t *testing.T) {
+	c := cfg(config.ProjectTypeCLI)
+	c.Features.Hooks = true
+	entries := scaffold.BuildDirectoryTree(c)
+	assertContainsPath(t, entries, ".pre-commit-config.yaml")
+	assertContainsPath(t, entries, ".commitlintrc.yml")
+
+	c.Hooks.Manager = config.HookManagerLefthook
+	entries = scaffold.BuildDirectoryTree(c)
+	assertContainsPath(t, entries, "lefthook.yml")
+	assertNotContainsPrefix(t, entries, ".pre-commit-config.yaml")
+}
+
+func TestBuildDirectoryTree_Codeowners(t *testing.T) {
+	c := cfg(config.ProjectTypeCLI)
+	assertNotContainsPrefix(t, scaffold.BuildDirectoryTree(c), ".github/CODEOWNERS")
+
+	c.RequiredVars = map[string]config.VarSpec{"codeowners": {Default: "@acme/platform"}}
+	files, err := scaffold.RenderAll(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := files[".github/CODEOWNERS"]; !strings.Contains(got, "* @acme/platform\n") {
+		t.Errorf("CODEOWNERS = %q, want the default owner", got)
+	}
+}
+
 func TestBuildDirectoryTree_PublicProject(t *testing.T) {
 	c := cfg(config.ProjectTypeLibrary)
 	c.Visibility = config.VisibilityPublic
@@ -88,6 +173,24 @@ func TestBuildDirectoryTree_SecureProject(t *testing.T) {
 	assertContainsPath(t, entries, "SECURITY.md")
 }
 
+func TestBuildDirectoryTree_TaskRunner(t *testing.T) {
+	cases := []struct {
+		runner config.TaskRunner
+		path   string
+	}{
+		{"", "Makefile"},
+		{config.TaskRunnerMake, "Makefile"},
+		{config.TaskRunnerTask, "Taskfile.yml"},
+		{config.TaskRunnerJust, "justfile"},
+	}
+	for _, tc := range cases {
+		c := cfg(config.ProjectTypeAPI)
+		c.TaskRunner = tc.runner
+		entries := scaffold.BuildDirectoryTree(c)
+		assertContainsPath(t, entries, tc.path)
+	}
+}
+
 // assertContainsPath fails if no entry has the given path.
 func assertContainsPath(t *testing.T, entries []scaffold.DirEntry, path string) {
 	t.Helper()
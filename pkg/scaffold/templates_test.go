@@ -2,6 +2,7 @@ package scaffold_test
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/had-nu/lazy.go/pkg/config"
@@ -13,10 +14,25 @@ type tmplData struct {
 	Year        int
 	LibName     string
 	ServiceName string
+	MainPackage string
+	VersionVar  string
+	Vars        map[string]any
+	GoVersion   string
+	Images      scaffold.DockerImages
 }
 
 func newTmplData(cfg *config.ProjectConfig) tmplData {
-	return tmplData{Config: cfg, Year: 2026, LibName: "testapp", ServiceName: cfg.Name}
+	return tmplData{
+		Config:      cfg,
+		Year:        2026,
+		LibName:     "testapp",
+		ServiceName: cfg.Name,
+		MainPackage: "./cmd/server",
+		VersionVar:  "main.version",
+		Vars:        cfg.TemplateVars(),
+		GoVersion:   scaffold.GoVersion,
+		Images:      scaffold.ImagesFor(cfg, nil),
+	}
 }
 
 func apicfg() *config.ProjectConfig {
@@ -47,6 +63,28 @@ func TestRenderTemplate_Readme(t *testing.T) {
 	}
 }
 
+func TestRenderTemplate_ReadmeVars(t *testing.T) {
+	cfg := apicfg()
+	cfg.Vars = map[string]string{"team": "Payments", "slack_channel": "#payments"}
+	out, err := scaffold.RenderTemplate("readme.tmpl", newTmplData(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## Project Information", "| slack_channel | #payments |", "| team | Payments |"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("readme missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = scaffold.RenderTemplate("readme.tmpl", newTmplData(apicfg()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "Project Information") {
+		t.Error("readme lists variables when none are set")
+	}
+}
+
 func TestRenderTemplate_Gomod(t *testing.T) {
 	out, err := scaffold.RenderTemplate("gomod.tmpl", newTmplData(apicfg()))
 	if err != nil {
@@ -65,6 +103,35 @@ func TestRenderTemplate_Workflow(t *testing.T) {
 	if len(out) == 0 {
 		t.Error("workflow.tmpl: empty output")
 	}
+	if !strings.Contains(out, `branches: ["main"]`) {
+		t.Errorf("workflow.tmpl: CI does not run on the default branch:\n%s", out)
+	}
+
+	cfg := apicfg()
+	cfg.GitHub.DefaultBranch = "trunk"
+	out, err = scaffold.RenderTemplate("workflow.tmpl", newTmplData(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, `branches: ["trunk"]`) {
+		t.Errorf("workflow.tmpl: CI does not run on github.default_branch:\n%s", out)
+	}
+}
+
+func TestCIJobs(t *testing.T) {
+	cfg := apicfg()
+	jobs, err := scaffold.CIJobs(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(jobs, ", "); got != "Test, Security Scan" {
+		t.Errorf("CIJobs = %s, want Test, Security Scan", got)
+	}
+
+	cfg.Features.GitHubActions = false
+	if jobs, err := scaffold.CIJobs(cfg); err != nil || jobs != nil {
+		t.Errorf("CIJobs without GitHub Actions = %v, %v", jobs, err)
+	}
 }
 
 func TestRenderTemplate_Golangci(t *testing.T) {
@@ -97,6 +164,148 @@ func TestRenderTemplate_Dockerfile(t *testing.T) {
 	}
 }
 
+func TestRenderTemplate_TaskRunners(t *testing.T) {
+	for _, name := range []string{"makefile.tmpl", "taskfile.tmpl", "justfile.tmpl"} {
+		out, err := scaffold.RenderTemplate(name, newTmplData(apicfg()))
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		for _, target := range []string{"build", "test", "lint", "clean", "generate", "docker-build", "migrate"} {
+			if !strings.Contains(out, target+":") {
+				t.Errorf("%s: missing %q target", name, target)
+			}
+		}
+	}
+}
+
+func TestRenderAll_MakefileBuildsMainPackage(t *testing.T) {
+	cases := map[config.ProjectType]string{
+		config.ProjectTypeCLI:          "MAIN    := .",
+		config.ProjectTypeAPI:          "MAIN    := ./cmd/server",
+		config.ProjectTypeMicroservice: "MAIN    := ./cmd/service",
+		config.ProjectTypeWorker:       "MAIN    := ./cmd/worker",
+		config.ProjectTypeSecurity:     "MAIN    := .",
+	}
+	for typ, want := range cases {
+		c := apicfg()
+		c.Type = typ
+		files, err := scaffold.RenderAll(c)
+		if err != nil {
+			t.Fatalf("RenderAll(%s): %v", typ, err)
+		}
+		if !strings.Contains(files["Makefile"], want) {
+			t.Errorf("%s Makefile: missing %q", typ, want)
+		}
+	}
+}
+
+func TestRenderAll_RouterAndQueue(t *testing.T) {
+	c := apicfg()
+	c.Type = config.ProjectTypeMicroservice
+	c.Features.Tests = true
+	c.API.Router = config.RouterChi
+	c.Worker.Queue = config.QueueNATS
+	files, err := scaffold.RenderAll(c)
+	if err != nil {
+		t.Fatalf("RenderAll: %v", err)
+	}
+	for path, want := range map[string]string{
+		"go.mod":                           "\tgithub.com/go-chi/chi/v5 v5.1.0\n\tgithub.com/nats-io/nats.go v1.34.1\n",
+		"cmd/service/main.go":              "mux := chi.NewRouter()",
+		"internal/handler/handler.go":      "func Register(r chi.Router, cfg *config.Config)",
+		"internal/handler/handler_test.go": "func newMux() chi.Router",
+		"internal/queue/queue.go":          "QueueSubscribeSync",
+		"internal/config/config.go":        `getenv("QUEUE_URL", "nats://localhost:4222")`,
+	} {
+		if !strings.Contains(files[path], want) {
+			t.Errorf("%s: missing %q:\n%s", path, want, files[path])
+		}
+	}
+
+	c.Type = config.ProjectTypeAPI
+	files, err = scaffold.RenderAll(c)
+	if err != nil {
+		t.Fatalf("RenderAll: %v", err)
+	}
+	if _, ok := files["internal/queue/queue.go"]; ok || strings.Contains(files["go.mod"], "nats") {
+		t.Error("API project generated a queue")
+	}
+}
+
+func TestRenderAll_BinaryName(t *testing.T) {
+	c := apicfg()
+	c.Type = config.ProjectTypeCLI
+	c.CLI.Binary = "tool"
+	files, err := scaffold.RenderAll(c)
+	if err != nil {
+		t.Fatalf("RenderAll: %v", err)
+	}
+	if !strings.Contains(files["Makefile"], "BINARY  := tool") || !strings.Contains(files["cmd/root.go"], `Use:   "tool"`) {
+		t.Errorf("binary name not used:\n%s", files["Makefile"])
+	}
+	if got := scaffold.Dependencies(c); len(got) != 1 || !strings.HasPrefix(got[0], "github.com/spf13/cobra ") {
+		t.Errorf("Dependencies = %v, want cobra", got)
+	}
+}
+
+func TestRenderAll_LibraryMakefileHasNoBinary(t *testing.T) {
+	c := apicfg()
+	c.Type = config.ProjectTypeLibrary
+	c.Features.Tests = true
+	files, err := scaffold.RenderAll(c)
+	if err != nil {
+		t.Fatalf("RenderAll: %v", err)
+	}
+	mk := files["Makefile"]
+	if strings.Contains(mk, "MAIN") || strings.Contains(mk, "docker-build") {
+		t.Errorf("library Makefile should not build a binary:\n%s", mk)
+	}
+}
+
+func TestRenderAll_DockerfileMatchesType(t *testing.T) {
+	api := apicfg()
+	files, err := scaffold.RenderAll(api)
+	if err != nil {
+		t.Fatalf("RenderAll: %v", err)
+	}
+	df := files["Dockerfile"]
+	for _, want := range []string{"./cmd/server", "EXPOSE 8080", "HEALTHCHECK", "USER 65532:65532", "distroless/static:nonroot", `org.opencontainers.image.licenses="MIT"`} {
+		if !strings.Contains(df, want) {
+			t.Errorf("API Dockerfile: missing %q", want)
+		}
+	}
+
+	cli := apicfg()
+	cli.Type = config.ProjectTypeCLI
+	cli.Docker.Base = config.DockerBaseScratch
+	files, err = scaffold.RenderAll(cli)
+	if err != nil {
+		t.Fatalf("RenderAll: %v", err)
+	}
+	df = files["Dockerfile"]
+	if strings.Contains(df, "EXPOSE") || strings.Contains(df, "HEALTHCHECK") {
+		t.Errorf("CLI Dockerfile should not expose a port:\n%s", df)
+	}
+	if !strings.Contains(df, "FROM scratch") || !strings.Contains(df, "USER 65532:65532") {
+		t.Errorf("CLI Dockerfile: expected non-root scratch image:\n%s", df)
+	}
+}
+
+func TestRenderTemplate_HooksSecretScan(t *testing.T) {
+	c := apicfg()
+	c.Features.SAST = false
+	c.Criticality = config.CriticalitySecurity
+	for _, name := range []string{"precommit.tmpl", "lefthook.tmpl"} {
+		out, err := scaffold.RenderTemplate(name, newTmplData(c))
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if !strings.Contains(out, "gitleaks") {
+			t.Errorf("%s: security-critical projects must scan for secrets", name)
+		}
+	}
+}
+
 func TestGenerate_CreatesFiles(t *testing.T) {
 	dir := t.TempDir()
 	outDir := dir + "/testapp"
@@ -24,6 +24,49 @@ func EnforceSecurity(cfg *config.ProjectConfig) {
 	if cfg.Features.GitHubActions {
 		cfg.Features.Dependabot = true
 	}
+	// Secret scanning must run before code leaves the workstation.
+	if cfg.Criticality == config.CriticalitySecurity {
+		cfg.Features.Hooks = true
+	}
+}
+
+// Enforcement is a feature EnforceSecurity turns on that was off, and why.
+type Enforcement struct {
+	Feature string // key under features in lazygo.yml, e.g. "sast"
+	Label   string // display name, e.g. "SAST"
+	Reason  string
+}
+
+// String returns the enforcement as "SAST forced on because ...".
+func (e Enforcement) String() string {
+	return e.Label + " forced on because " + e.Reason
+}
+
+// Enforcements returns the features EnforceSecurity would turn on in cfg,
+// in the order lazygo.yml lists them. cfg is not modified.
+func Enforcements(cfg *config.ProjectConfig) []Enforcement {
+	enforced := *cfg
+	EnforceSecurity(&enforced)
+
+	because := "criticality=" + string(cfg.Criticality)
+	features := []struct {
+		key, label    string
+		before, after bool
+		reason        string
+	}{
+		{"static_analysis", "Static analysis", cfg.Features.StaticAnalysis, enforced.Features.StaticAnalysis, because},
+		{"dependabot", "Dependabot", cfg.Features.Dependabot, enforced.Features.Dependabot, because + " and GitHub Actions is on"},
+		{"tests", "Tests", cfg.Features.Tests, enforced.Features.Tests, because},
+		{"sast", "SAST", cfg.Features.SAST, enforced.Features.SAST, because},
+		{"hooks", "Git hooks", cfg.Features.Hooks, enforced.Features.Hooks, because + ": secrets are scanned before code leaves the workstation"},
+	}
+	var out []Enforcement
+	for _, f := range features {
+		if !f.before && f.after {
+			out = append(out, Enforcement{Feature: f.key, Label: f.label, Reason: f.reason})
+		}
+	}
+	return out
 }
 
 // GolangCIConfig generates a .golangci.yml configuration string.
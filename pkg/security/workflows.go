@@ -18,7 +18,13 @@ func GenerateCIWorkflow(cfg *config.ProjectConfig) string {
 	sb.WriteString("      - uses: actions/checkout@v4\n")
 	sb.WriteString("      - uses: actions/setup-go@v5\n        with:\n          go-version: \"1.22\"\n          cache: true\n")
 	sb.WriteString("      - run: go mod download\n")
-	sb.WriteString("      - run: go test -v -race -coverprofile=coverage.out ./...\n")
+	switch cfg.Runner() {
+	case config.TaskRunnerTask:
+		sb.WriteString("      - uses: arduino/setup-task@v2\n")
+	case config.TaskRunnerJust:
+		sb.WriteString("      - uses: extractions/setup-just@v2\n")
+	}
+	fmt.Fprintf(&sb, "      - run: %s test\n", cfg.Runner())
 
 	if cfg.Features.StaticAnalysis {
 		sb.WriteString("\n  lint:\n    name: Lint\n    runs-on: ubuntu-latest\n    steps:\n")
@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/had-nu/lazy.go/pkg/wizard"
+)
+
+// Commands entered as answers: back returns to the previous question and
+// help explains the current one.
+const (
+	backCommand = "<"
+	helpCommand = "?"
+)
+
+// RunAccessible runs the wizard in m as plain line-based prompts, for
+// screen readers, dumb terminals and CI logs: no alternate screen, cursor
+// movement or colour. Each question is printed to out and answered with a
+// line from in. It asks the TUI's questions, with the same choices and
+// validation, and returns m as the TUI would leave it, Done when the wizard
+// completed. If in ends first, the progress is saved to the session file as
+// on ctrl+c and io.ErrUnexpectedEOF returned.
+func RunAccessible(m Model, in io.Reader, out io.Writer) (Model, error) {
+	m.styles = plainStyles()
+	if m.login != nil {
+		m.suggest.GitHubLogin = m.login()
+	}
+
+	fmt.Fprintln(out, "lazy.go — Go Project Generator")
+	fmt.Fprintf(out, "Answer each question and press enter. Enter %s to go back to the previous question, %s for help.\n", backCommand, helpCommand)
+
+	sc := bufio.NewScanner(in)
+	for {
+		printQuestion(out, m)
+		if !sc.Scan() {
+			m.saveSession()
+			if err := sc.Err(); err != nil {
+				return m, err
+			}
+			return m, io.ErrUnexpectedEOF
+		}
+		line := strings.TrimSpace(sc.Text())
+
+		if line == backCommand {
+			if len(m.state.History) == 0 {
+				fmt.Fprintln(out, "Error: this is the first question")
+				continue
+			}
+			next, _ := m.back()
+			m = next.(Model)
+			continue
+		}
+
+		if line == helpCommand {
+			fmt.Fprintln(out, renderHelp(m))
+			continue
+		}
+
+		step := m.state.CurrentStep
+		if step == wizard.StepFeatures && line != "" {
+			if err := m.toggleFeatures(line); err != nil {
+				fmt.Fprintln(out, "Error:", err)
+			}
+			continue
+		}
+		before := m.inputValue()
+		if err := m.answerLine(line); err != nil {
+			fmt.Fprintln(out, "Error:", err)
+			continue
+		}
+		if step == wizard.StepConfirm && wizard.ConfirmChoices()[m.selection].Value == "cancel" {
+			next, _ := m.confirm() // discards the session
+			return next.(Model), nil
+		}
+
+		next, _ := m.advance()
+		m = next.(Model)
+		if m.validErr != "" {
+			fmt.Fprintln(out, "Error:", m.validErr)
+			m.setInputValue(before) // offer the last good answer again
+		}
+		if m.done {
+			return m, nil
+		}
+	}
+}
+
+// printQuestion writes the current step as plain text, ending with a line
+// saying how to answer it.
+func printQuestion(out io.Writer, m Model) {
+	step := m.state.CurrentStep
+	n, total := wizard.Position(m.state)
+	fmt.Fprintf(out, "\nQuestion %d of %d, %s.\n", n, total, step)
+
+	switch {
+	case isTextInputStep(step):
+		prompt := stepPrompt(step)
+		if step == wizard.StepVars {
+			if missing := wizard.MissingVars(m.state); len(missing) > 0 {
+				prompt = wizard.VarPrompt(m.state, missing[0])
+			}
+		}
+		fmt.Fprintln(out, prompt)
+		if step == wizard.StepGitHubTopics {
+			fmt.Fprintln(out, "Separate topics with commas.")
+			if s := m.topicSuggestions(); len(s) > 0 {
+				fmt.Fprintln(out, "Suggested:", strings.Join(s, ", "))
+			}
+		}
+		if def := m.defaultText(); def != "" {
+			fmt.Fprintf(out, "Press enter for %s, or type an answer.\n", def)
+		}
+
+	case step == wizard.StepFeatures:
+		fmt.Fprintln(out, "Select features to enable:")
+		for i, fc := range wizard.FeatureChoicesFor(m.state.ProjectType) {
+			state := "off"
+			if m.toggles[fc.Key] {
+				state = "on"
+			}
+			fmt.Fprintf(out, "%d. %s: %s\n", i+1, fc.Label, state)
+		}
+		fmt.Fprintln(out, "Enter the numbers of features to switch, separated by spaces, or press enter to keep these.")
+
+	case step == wizard.StepReview:
+		fmt.Fprintln(out, "Review your answers. Choose one to change it:")
+		fmt.Fprintln(out, "0. Looks good, continue")
+		for i, row := range wizard.ReviewRows(m.state) {
+			fmt.Fprintf(out, "%d. %s: %s\n", i+1, row.Label, row.Value)
+		}
+		fmt.Fprintf(out, "Enter a number, or press enter for %d.\n", m.selection)
+
+	default:
+		if step == wizard.StepConfirm {
+			fmt.Fprintln(out, m.Summary())
+		}
+		fmt.Fprintln(out, stepPrompt(step))
+		for i, c := range m.stepChoices(step) {
+			if c.Description != "" {
+				fmt.Fprintf(out, "%d. %s. %s.\n", i+1, c.Label, c.Description)
+			} else {
+				fmt.Fprintf(out, "%d. %s\n", i+1, c.Label)
+			}
+		}
+		fmt.Fprintf(out, "Enter a number, or press enter for %d.\n", m.selection+1)
+	}
+}
+
+// defaultText returns the answer an empty line gives on a text step: the
+// answer already there, or else the suggestion.
+func (m Model) defaultText() string {
+	if v := m.inputValue(); v != "" {
+		return v
+	}
+	return m.suggestion()
+}
+
+// answerLine records line as the answer to the current step, leaving it to
+// advance to validate it. An empty line keeps the default.
+func (m *Model) answerLine(line string) error {
+	step := m.state.CurrentStep
+	if isTextInputStep(step) {
+		if line == "" {
+			line = m.defaultText()
+		}
+		m.setInputValue(line)
+		return nil
+	}
+	if line == "" {
+		return nil
+	}
+
+	first, last := 1, m.maxSelection()+1
+	if step == wizard.StepReview {
+		first, last = 0, m.maxSelection()
+	}
+	n, err := strconv.Atoi(line)
+	if err != nil {
+		// A list answer can also be given by its value, e.g. "chi".
+		for i, c := range m.stepChoices(step) {
+			if strings.EqualFold(c.Value, line) {
+				m.selection = i
+				return nil
+			}
+		}
+	}
+	if err != nil || n < first || n > last {
+		return fmt.Errorf("enter a number from %d to %d", first, last)
+	}
+	m.selection = n - first
+	return nil
+}
+
+// toggleFeatures switches the features whose numbers are listed in line.
+func (m *Model) toggleFeatures(line string) error {
+	fcs := wizard.FeatureChoicesFor(m.state.ProjectType)
+	fields := strings.FieldsFunc(line, func(r rune) bool { return r == ' ' || r == ',' })
+	var keys []string
+	for _, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 1 || n > len(fcs) {
+			return fmt.Errorf("%q is not a feature number from 1 to %d", f, len(fcs))
+		}
+		keys = append(keys, fcs[n-1].Key)
+	}
+	for _, key := range keys {
+		m.toggles[key] = !m.toggles[key]
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/had-nu/lazy.go/pkg/scaffold"
+	"github.com/had-nu/lazy.go/pkg/wizard"
+)
+
+func TestRunAccessible(t *testing.T) {
+	// Answers up to the project type; an API then asks seven list
+	// questions with defaults before the review and confirmation.
+	const identity = "svc\nexample.com/svc\n\nJo\napi\n"
+	defaults := strings.Repeat("\n", 6) + "no\n"
+	tests := []struct {
+		name    string
+		in      string
+		wantErr error
+		wantOut []string
+		check   func(t *testing.T, m Model)
+	}{
+		{
+			name:    "defaults",
+			in:      identity + defaults + "\n\n",
+			wantOut: []string{"Question 1 of 13, Project Name.", "Enter a number, or press enter for 1."},
+			check: func(t *testing.T, m Model) {
+				if !m.Done() || m.State().ProjectType != "api" {
+					t.Errorf("done %v, type %q", m.Done(), m.State().ProjectType)
+				}
+			},
+		},
+		{
+			name:    "cancel",
+			in:      identity + defaults + "\ncancel\n",
+			wantOut: []string{"Review your answers."},
+			check: func(t *testing.T, m Model) {
+				if m.Done() {
+					t.Error("cancelled wizard reported done")
+				}
+			},
+		},
+		{
+			name:    "back",
+			in:      "svc\n<\nother\n",
+			wantErr: io.ErrUnexpectedEOF,
+			wantOut: []string{"Question 2 of 13, Module Path.", "Press enter for svc, or type an answer."},
+			check: func(t *testing.T, m Model) {
+				if s := m.State(); s.ProjectName != "other" || s.CurrentStep != wizard.StepModulePath {
+					t.Errorf("name %q at %s", s.ProjectName, s.CurrentStep)
+				}
+			},
+		},
+		{
+			name:    "back on the first question",
+			in:      "<\n",
+			wantErr: io.ErrUnexpectedEOF,
+			wantOut: []string{"Error: this is the first question"},
+		},
+		{
+			name:    "help",
+			in:      "?\n",
+			wantErr: io.ErrUnexpectedEOF,
+			wantOut: []string{"Help — Project Name"},
+		},
+		{
+			name:    "invalid answer is asked again",
+			in:      "Bad Name!\nsvc\n",
+			wantErr: io.ErrUnexpectedEOF,
+			wantOut: []string{"Error: ", "Question 2 of 13, Module Path."},
+		},
+		{
+			name:    "list answer by value",
+			in:      "svc\nexample.com/svc\n\nJo\nworker\n",
+			wantErr: io.ErrUnexpectedEOF,
+			check: func(t *testing.T, m Model) {
+				if got := m.State().ProjectType; got != "worker" {
+					t.Errorf("type = %q, want worker", got)
+				}
+			},
+		},
+		{
+			name:    "number out of range",
+			in:      "svc\nexample.com/svc\n\nJo\n99\n",
+			wantErr: io.ErrUnexpectedEOF,
+			wantOut: []string{"Error: enter a number from 1 to"},
+		},
+	}
+	// Styled output would be coloured here, as in a terminal.
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			m, err := RunAccessible(New(), strings.NewReader(tt.in), &out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v\n%s", err, tt.wantErr, out.String())
+			}
+			if strings.Contains(out.String(), "\x1b[") {
+				t.Errorf("output has ANSI escape codes:\n%q", out.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, m)
+			}
+		})
+	}
+}
+
+func TestRunAccessible_DefaultsMatchHeadless(t *testing.T) {
+	// The wizard's required answers, then every default but GitHub, which
+	// headless runs only create a repository for when asked to.
+	in := strings.NewReader("svc\nexample.com/svc\n\nJo\napi\n" + strings.Repeat("\n", 6) + "no\n" + strings.Repeat("\n", 10))
+	var out bytes.Buffer
+	m, err := RunAccessible(New(), in, &out)
+	if err != nil {
+		t.Fatalf("RunAccessible: %v\n%s", err, out.String())
+	}
+	if !m.Done() {
+		t.Fatalf("wizard did not finish:\n%s", out.String())
+	}
+	got := wizard.BuildConfig(m.State())
+
+	want := wizard.DefaultConfig()
+	want.Name, want.ModulePath, want.Author, want.Type = "svc", "example.com/svc", "Jo", "api"
+	wizard.Finalize(want)
+
+	gotFiles, err := scaffold.RenderAll(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFiles, err := scaffold.RenderAll(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range wantFiles {
+		if gotFiles[path] != content {
+			t.Errorf("%s differs from a headless run:\n%s", path, gotFiles[path])
+		}
+	}
+	for path := range gotFiles {
+		if _, ok := wantFiles[path]; !ok {
+			t.Errorf("%s is not generated by a headless run", path)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/had-nu/lazy.go/pkg/wizard"
+)
+
+// helpWidth is the widest the help panel's prose is wrapped to.
+const helpWidth = 72
+
+// stepHelp explains what the answer to a step decides.
+func stepHelp(step wizard.Step) string {
+	switch step {
+	case wizard.StepPreset:
+		return "A preset answers everything but the name and module path with a combination of choices that work well together."
+	case wizard.StepProjectName:
+		return "Names the project directory, the README title and, unless you choose another, the executable."
+	case wizard.StepModulePath:
+		return "The import path declared in go.mod, usually the repository URL without https://."
+	case wizard.StepDescription:
+		return "Goes into the README and, with GitHub on, the repository description."
+	case wizard.StepAuthor:
+		return "Appears in the README and the license's copyright line."
+	case wizard.StepProjectType:
+		return "Decides the layout: which packages, entry points and tests are generated."
+	case wizard.StepRouter:
+		return "The router the server's handlers are registered on."
+	case wizard.StepQueue:
+		return "Where the worker gets its jobs from. A queue adds a consumer in internal/queue and its client to go.mod."
+	case wizard.StepBinary:
+		return "The name of the built executable, used by the build files, the Dockerfile and .gitignore."
+	case wizard.StepVisibility:
+		return "Who can see the code. It decides the suggested license and whether community files are generated."
+	case wizard.StepCriticality:
+		return "How much rides on the project. Security enforcement turns safeguards on to match, whatever is chosen under Features."
+	case wizard.StepFeatures:
+		return "Tooling generated alongside the code. Features the criticality requires are turned back on at generation."
+	case wizard.StepDockerBase:
+		return "The runtime image the Dockerfile's final stage copies the binary into."
+	case wizard.StepHookManager:
+		return "The tool that runs the git hooks: formatting, linting and secret scanning before each commit."
+	case wizard.StepTaskRunner:
+		return "The file holding the build, test and lint commands that developers and CI run."
+	case wizard.StepLicense:
+		return "The LICENSE file written into the project."
+	case wizard.StepGitHub:
+		return "Whether lazy.go creates a GitHub repository for the project once it is generated."
+	case wizard.StepGitHubOwner:
+		return "The account or organisation the repository is created under. Leave it empty for your own account."
+	case wizard.StepGitHubRepo:
+		return "The repository's name on GitHub. It can differ from the project name, for example to add a -go suffix."
+	case wizard.StepGitHubTopics:
+		return "Topics help people find the repository on GitHub. The suggestions follow from the project type, router, queue and features."
+	case wizard.StepGitHubBranch:
+		return "The branch the initial commit is pushed to, which becomes the repository's default, and the one CI runs on."
+	case wizard.StepGitHubPush:
+		return "Whether the generated project is committed and pushed to the new repository."
+	case wizard.StepVars:
+		return "A template variable the configuration requires. Its value is substituted into the generated files."
+	case wizard.StepReview:
+		return "Every answer so far. Choose one to change it; questions that follow up on it are asked again."
+	case wizard.StepConfirm:
+		return "The configuration exactly as it will be generated, after security enforcement."
+	}
+	return ""
+}
+
+// alternatives returns the state with each answer the current step offers
+// applied, and the index of the highlighted one. On the features step they
+// are the highlighted feature off and on. Steps without a fixed set of
+// answers return nil.
+func (m Model) alternatives() ([]wizard.WizardState, int) {
+	switch step := m.state.CurrentStep; {
+	case step == wizard.StepFeatures:
+		key := wizard.FeatureChoicesFor(m.state.ProjectType)[m.selection].Key
+		var alts []wizard.WizardState
+		for _, on := range []bool{false, true} {
+			p := m
+			p.toggles = maps.Clone(m.toggles)
+			p.toggles[key] = on
+			alts = append(alts, p.pendingState())
+		}
+		return alts, 1
+	case step == wizard.StepReview, step == wizard.StepConfirm, isTextInputStep(step):
+		return nil, 0
+	}
+
+	var alts []wizard.WizardState
+	for i := range m.stepChoices(m.state.CurrentStep) {
+		p := m
+		p.selection = i
+		alts = append(alts, p.pendingState())
+	}
+	return alts, m.selection
+}
+
+// renderHelp renders the help panel: what the current step decides and
+// what the highlighted answer adds to the generated project, read off the
+// tree, CI workflow and security enforcement it produces.
+func renderHelp(m Model) string {
+	st := m.styles
+	step := m.state.CurrentStep
+	wrap := lipgloss.NewStyle().Width(min(max(m.width-6, 20), helpWidth))
+
+	var sb strings.Builder
+	sb.WriteString(st.primary.Render("Help — "+step.String()) + "  " + st.hint.Render(helpKey(step)+" → close") + "\n\n")
+	sb.WriteString(wrap.Render(stepHelp(step)) + "\n")
+
+	if alts, cur := m.alternatives(); alts != nil {
+		effect := wizard.Effects(alts)[cur]
+		var heading string
+		if step == wizard.StepFeatures {
+			heading = "With " + wizard.FeatureChoicesFor(m.state.ProjectType)[m.selection].Label + " on"
+		} else {
+			heading = "With " + m.stepChoices(step)[cur].Label
+		}
+		sb.WriteString("\n" + st.secondary.Render(heading+":") + "\n")
+		if effect.Empty() {
+			sb.WriteString(st.muted.Render("  nothing is generated that the other answers lack") + "\n")
+		}
+		for _, f := range effect.Files {
+			sb.WriteString(st.success.Render("  + "+f) + "\n")
+		}
+		if len(effect.CIJobs) > 0 {
+			sb.WriteString(st.unselected.Render("  CI jobs: "+strings.Join(effect.CIJobs, ", ")) + "\n")
+		}
+		for _, e := range effect.Enforced {
+			sb.WriteString(st.unselected.Render("  "+e.String()) + "\n")
+		}
+	}
+	if step == wizard.StepLicense {
+		pending := m.pendingState()
+		cfg := wizard.BuildConfig(pending)
+		sb.WriteString("\n" + st.unselected.Render(wrap.Render(string(cfg.License)+": "+wizard.LicenseReason(pending))) + "\n")
+	}
+	return st.box.Render(strings.TrimRight(sb.String(), "\n"))
+}
@@ -2,106 +2,152 @@ package tui
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 
+	"github.com/had-nu/lazy.go/pkg/config"
+	"github.com/had-nu/lazy.go/pkg/scaffold"
 	"github.com/had-nu/lazy.go/pkg/wizard"
 )
 
-// ---- Styles ----------------------------------------------------------------
-
-var (
-	stylePrimary = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#7C3AED")).
-			Bold(true)
-
-	styleSecondary = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#A78BFA"))
-
-	styleMuted = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280"))
-
-	styleSuccess = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#10B981")).
-			Bold(true)
-
-	styleError = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#EF4444"))
-
-	styleBox = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#7C3AED")).
-			Padding(1, 2)
-
-	styleHeader = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(lipgloss.Color("#7C3AED")).
-			Padding(0, 2).
-			Bold(true)
-
-	styleSelected = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#7C3AED")).
-			Bold(true)
-
-	styleUnselected = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#D1D5DB"))
-
-	styleProgress = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#A78BFA"))
-
-	styleHint = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#4B5563")).
-			Italic(true)
-)
-
 // ---- Model -----------------------------------------------------------------
 
 // Model is the top-level BubbleTea model for the wizard.
 type Model struct {
 	state     wizard.WizardState
 	textInput textinput.Model
-	selection int    // cursor index for list/toggle steps
-	toggles   []bool // for feature checkboxes
+	tags      []string        // topics added so far on the topics step
+	selection int             // cursor index for list/toggle steps
+	toggles   map[string]bool // for feature checkboxes, by feature key
 	validErr  string
 	done      bool
+	saveOnly  bool // done, but write lazygo.yml instead of generating
 	width     int
+	height    int
+	presets   []scaffold.Preset    // offered by the preset step
+	answered  map[wizard.Step]bool // steps answered in this session
+	preview   preview
+	suggest   wizard.Suggestions // guessed answers offered on text steps
+	login     func() string      // looks up the GitHub login in the background
+	session   string             // file the run is saved to after every step, if any
+	styles    styles
+	help      bool // the help panel is open
 }
 
 // New creates a fresh TUI Model.
 func New() Model {
+	return NewFromState(wizard.StateFromConfig(wizard.DefaultConfig()), nil)
+}
+
+// NewFromState creates a TUI Model whose steps open on the answers already
+// in state, e.g. those pre-filled from a defaults file. The wizard opens on
+// a preset step offering presets, skipped when there are none.
+func NewFromState(state wizard.WizardState, presets []scaffold.Preset) Model {
+	if state.CurrentStep == wizard.StepPreset && len(presets) == 0 {
+		state.CurrentStep = wizard.NextStep(state)
+	}
+
 	ti := textinput.New()
 	ti.Placeholder = "type here..."
 	ti.CharLimit = 128
+	ti.ShowSuggestions = true
 	ti.Focus()
 
-	state := wizard.NewWizardState()
-	features := wizard.DefaultFeatures()
-	state.Features = features
-
-	// Build toggles slice from FeatureChoices order.
-	fcs := wizard.FeatureChoices()
-	toggles := make([]bool, len(fcs))
-	for i, fc := range fcs {
-		toggles[i] = features[fc.Key]
+	toggles := make(map[string]bool)
+	for _, fc := range wizard.FeatureChoices() {
+		toggles[fc.Key] = state.Features[fc.Key]
 	}
 
-	return Model{
+	m := Model{
 		state:     state,
 		textInput: ti,
 		toggles:   toggles,
 		width:     80,
+		presets:   presets,
+		answered:  make(map[wizard.Step]bool),
+		styles:    newStyles(DarkTheme),
+	}
+	m.prepareStepInput()
+	return m
+}
+
+// NewFromSession creates a TUI Model that resumes an interrupted run on the
+// step it stopped at, with its toggles, cursor and typed input.
+func NewFromSession(s wizard.Session, presets []scaffold.Preset) Model {
+	m := NewFromState(s.State, presets)
+	for _, step := range s.Answered {
+		m.answered[step] = true
+	}
+	m.prepareStepInput()
+	for key, on := range s.Toggles {
+		m.toggles[key] = on
+	}
+	m.selection = min(max(s.Selection, 0), m.maxSelection())
+	if isTextInputStep(m.state.CurrentStep) && s.Input != "" {
+		m.setInputValue(s.Input)
+		m.refreshSuggestion()
+	}
+	return m
+}
+
+// WithTheme returns m drawn in the colours of t.
+func (m Model) WithTheme(t Theme) Model {
+	m.styles = newStyles(t)
+	return m
+}
+
+// WithSession returns m saving its progress to path after every step and
+// when interrupted, so that NewFromSession can resume it. Cancelling from
+// the confirmation step removes the file.
+func (m Model) WithSession(path string) Model {
+	m.session = path
+	return m
+}
+
+// saveSession records the run in the session file, if there is one.
+func (m Model) saveSession() {
+	if m.session == "" {
+		return
+	}
+	s := wizard.Session{
+		State:     m.state,
+		Answered:  slices.Collect(maps.Keys(m.answered)),
+		Toggles:   m.toggles,
+		Selection: m.selection,
 	}
+	if isTextInputStep(m.state.CurrentStep) {
+		s.Input = m.inputValue()
+	}
+	// Losing the session only costs the ability to resume.
+	_ = wizard.SaveSession(m.session, s)
+}
+
+// WithSuggestions returns m offering s on the text steps. login, if not
+// nil, is run in the background when the wizard starts, to suggest a
+// module path under the user's GitHub account; it returns "" on failure.
+func (m Model) WithSuggestions(s wizard.Suggestions, login func() string) Model {
+	m.suggest, m.login = s, login
+	m.refreshSuggestion()
+	return m
 }
 
 // ---- Messages --------------------------------------------------------------
 
+// loginMsg carries the GitHub login found in the background.
+type loginMsg string
+
 // ---- Init ------------------------------------------------------------------
 
 func (m Model) Init() tea.Cmd {
-	return textinput.Blink
+	if m.login == nil {
+		return textinput.Blink
+	}
+	login := m.login
+	return tea.Batch(textinput.Blink, func() tea.Msg { return loginMsg(login()) })
 }
 
 // ---- Update ----------------------------------------------------------------
@@ -110,6 +156,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
+		m.height = msg.Height
+
+	case loginMsg:
+		m.suggest.GitHubLogin = string(msg)
+		m.refreshSuggestion()
+		return m, nil
+
+	case previewMsg:
+		if msg.path == m.preview.open {
+			m.preview.content = msg.content
+		}
+		return m, nil
 
 	case tea.KeyMsg:
 		return m.handleKey(msg)
@@ -122,14 +180,62 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	isTextStep := isTextInputStep(m.state.CurrentStep)
+	if m.preview.focused {
+		return m.handlePreviewKey(msg)
+	}
+	if m.state.CurrentStep == wizard.StepGitHubTopics {
+		if next, ok := m.handleTopicKey(msg); ok {
+			return next, nil
+		}
+	}
 
 	switch msg.String() {
-	case "ctrl+c", "esc":
+	case "ctrl+c":
+		m.saveSession()
 		return m, tea.Quit
 
 	case "enter":
 		return m.advance()
 
+	case "f1":
+		m.help = !m.help
+		return m, nil
+
+	case "?":
+		// Text steps take ? as typed; f1 opens help there.
+		if !isTextStep {
+			m.help = !m.help
+			return m, nil
+		}
+
+	case "shift+tab", "esc":
+		if m.help && msg.String() == "esc" {
+			m.help = false
+			return m, nil
+		}
+		return m.back()
+
+	case "tab":
+		if isTextStep {
+			if s := m.suggestion(); s != "" && m.textInput.Value() == "" {
+				m.textInput.SetValue(s)
+				m.textInput.CursorEnd()
+				return m, nil
+			}
+			if len(m.textInput.MatchedSuggestions()) > 0 {
+				break // the input completes what was typed
+			}
+		}
+		if m.showPreview() {
+			m.preview.focused = true
+			return m, nil
+		}
+
+	case "backspace":
+		if !isTextStep || m.textInput.Value() == "" {
+			return m.back()
+		}
+
 	case "up", "k":
 		if !isTextStep && m.selection > 0 {
 			m.selection--
@@ -137,7 +243,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "down", "j":
 		if !isTextStep {
-			max := maxSelection(m.state.CurrentStep)
+			max := m.maxSelection()
 			if m.selection < max {
 				m.selection++
 			}
@@ -145,13 +251,18 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case " ":
 		if m.state.CurrentStep == wizard.StepFeatures {
-			m.toggles[m.selection] = !m.toggles[m.selection]
+			key := wizard.FeatureChoicesFor(m.state.ProjectType)[m.selection].Key
+			m.toggles[key] = !m.toggles[key]
 		}
 	}
 
 	var cmd tea.Cmd
 	if isTextStep {
+		before := m.textInput.Value()
 		m.textInput, cmd = m.textInput.Update(msg)
+		if m.textInput.Value() != before {
+			m.validErr = "" // checked live from here on
+		}
 	}
 	return m, cmd
 }
@@ -160,26 +271,97 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m Model) advance() (tea.Model, tea.Cmd) {
 	m.validErr = ""
 
+	if m.state.CurrentStep == wizard.StepReview && m.selection > 0 {
+		rows := wizard.ReviewRows(m.state)
+		m.state = wizard.Edit(m.state, rows[m.selection-1].Step)
+		m.prepareStepInput()
+		return m, textinput.Blink
+	}
+
+	if m.state.CurrentStep == wizard.StepConfirm {
+		return m.confirm()
+	}
+
 	if err := m.applyCurrentStep(); err != nil {
 		m.validErr = err.Error()
 		return m, nil
 	}
+	m.answered[m.state.CurrentStep] = true
 
-	next := wizard.NextStep(m.state)
-	if next == wizard.StepDone {
+	m.state = wizard.Forward(m.state)
+	if m.state.CurrentStep == wizard.StepDone {
 		m.done = true
 		return m, tea.Quit
 	}
 
-	m.state.CurrentStep = next
-	m.selection = 0
 	m.prepareStepInput()
+	m.saveSession()
+	return m, textinput.Blink
+}
+
+// confirm carries out the action selected on the confirmation step.
+func (m Model) confirm() (tea.Model, tea.Cmd) {
+	switch wizard.ConfirmChoices()[m.selection].Value {
+	case "generate":
+		if config.Check(wizard.BuildConfig(m.state)).Fails(false) {
+			m.validErr = "the configuration has errors; edit it before generating"
+			return m, nil
+		}
+		m.done = true
+		return m, tea.Quit
+	case "edit":
+		return m.back()
+	case "save":
+		m.done, m.saveOnly = true, true
+		return m, tea.Quit
+	default:
+		if m.session != "" {
+			_ = wizard.DiscardSession(m.session)
+		}
+		return m, tea.Quit
+	}
+}
+
+// back returns to the previous step with its answer restored.
+func (m Model) back() (tea.Model, tea.Cmd) {
+	from := m.state.CurrentStep
+	prev, ok := wizard.Back(m.state)
+	if !ok {
+		return m, nil
+	}
+	m.validErr = ""
+	m.state = prev
+	m.prepareStepInput()
+	if m.state.CurrentStep == wizard.StepReview {
+		m.selectReviewRow(from) // an edit was cancelled
+	}
+	m.saveSession()
 	return m, textinput.Blink
 }
 
 // applyCurrentStep reads controller input and stores answer in state.
 func (m *Model) applyCurrentStep() error {
 	switch m.state.CurrentStep {
+	case wizard.StepPreset:
+		choices := wizard.PresetChoices(m.presets)
+		if m.selection >= len(choices) {
+			return fmt.Errorf("invalid selection")
+		}
+		m.state.Preset = ""
+		for _, p := range m.presets {
+			if p.Name != choices[m.selection].Value {
+				continue
+			}
+			state, err := wizard.ApplyPreset(m.state, p)
+			if err != nil {
+				return err
+			}
+			m.state = state
+			for _, fc := range wizard.FeatureChoices() {
+				m.toggles[fc.Key] = state.Features[fc.Key]
+			}
+		}
+
 	case wizard.StepProjectName:
 		v := strings.TrimSpace(m.textInput.Value())
 		if err := wizard.ValidateProjectName(v); err != nil {
@@ -215,6 +397,27 @@ func (m *Model) applyCurrentStep() error {
 		}
 		m.state.ProjectType = choices[m.selection].Value
 
+	case wizard.StepRouter:
+		choices := wizard.RouterChoices()
+		if m.selection >= len(choices) {
+			return fmt.Errorf("invalid selection")
+		}
+		m.state.Router = choices[m.selection].Value
+
+	case wizard.StepQueue:
+		choices := wizard.QueueChoices()
+		if m.selection >= len(choices) {
+			return fmt.Errorf("invalid selection")
+		}
+		m.state.Queue = choices[m.selection].Value
+
+	case wizard.StepBinary:
+		v := strings.TrimSpace(m.textInput.Value())
+		if err := wizard.ValidateBinary(v); err != nil {
+			return err
+		}
+		m.state.Binary = v
+
 	case wizard.StepVisibility:
 		choices := wizard.VisibilityChoices()
 		if m.selection >= len(choices) {
@@ -230,10 +433,30 @@ func (m *Model) applyCurrentStep() error {
 		m.state.Criticality = choices[m.selection].Value
 
 	case wizard.StepFeatures:
-		fcs := wizard.FeatureChoices()
-		for i, fc := range fcs {
-			m.state.Features[fc.Key] = m.toggles[i]
+		for _, fc := range wizard.FeatureChoicesFor(m.state.ProjectType) {
+			m.state.Features[fc.Key] = m.toggles[fc.Key]
+		}
+
+	case wizard.StepDockerBase:
+		choices := wizard.DockerBaseChoices()
+		if m.selection >= len(choices) {
+			return fmt.Errorf("invalid selection")
+		}
+		m.state.DockerBase = choices[m.selection].Value
+
+	case wizard.StepHookManager:
+		choices := wizard.HookManagerChoices()
+		if m.selection >= len(choices) {
+			return fmt.Errorf("invalid selection")
 		}
+		m.state.HookManager = choices[m.selection].Value
+
+	case wizard.StepTaskRunner:
+		choices := wizard.TaskRunnerChoices()
+		if m.selection >= len(choices) {
+			return fmt.Errorf("invalid selection")
+		}
+		m.state.TaskRunner = choices[m.selection].Value
 
 	case wizard.StepLicense:
 		choices := wizard.LicenseChoices()
@@ -245,55 +468,202 @@ func (m *Model) applyCurrentStep() error {
 	case wizard.StepGitHub:
 		choices := []string{"yes", "no"}
 		m.state.GitHubEnable = choices[m.selection] == "yes"
-		m.state.GitHubPush = m.state.GitHubEnable
+		if !m.answered[wizard.StepGitHubPush] {
+			m.state.GitHubPush = m.state.GitHubEnable // push unless told otherwise
+		}
+
+	case wizard.StepGitHubOwner:
+		v := strings.TrimSpace(m.textInput.Value())
+		if err := wizard.ValidateGitHubOwner(v); err != nil {
+			return err
+		}
+		m.state.GitHubOwner = v
+
+	case wizard.StepGitHubRepo:
+		v := strings.TrimSpace(m.textInput.Value())
+		if err := wizard.ValidateRepoName(v); err != nil {
+			return err
+		}
+		m.state.GitHubRepo = v
+
+	case wizard.StepGitHubTopics:
+		topics, err := wizard.ParseTopics(m.inputValue())
+		if err != nil {
+			return err
+		}
+		m.state.GitHubTopics = topics
+
+	case wizard.StepGitHubBranch:
+		v := strings.TrimSpace(m.textInput.Value())
+		if err := wizard.ValidateBranch(v); err != nil {
+			return err
+		}
+		m.state.GitHubBranch = v
+
+	case wizard.StepGitHubPush:
+		m.state.GitHubPush = m.selection == 0
+
+	case wizard.StepVars:
+		missing := wizard.MissingVars(m.state)
+		if len(missing) == 0 {
+			return nil
+		}
+		if err := wizard.SetVar(&m.state, missing[0], m.textInput.Value()); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// prepareStepInput initialises text input for text-based steps.
+// prepareStepInput initialises the input for the current step, starting
+// from the answer already in state.
 func (m *Model) prepareStepInput() {
 	step := m.state.CurrentStep
+	m.selection = 0
 	if isTextInputStep(step) {
 		m.textInput.Reset()
 		m.textInput.Focus()
 		switch step {
 		case wizard.StepProjectName:
 			m.textInput.Placeholder = "e.g. my-service"
+			m.textInput.SetValue(m.state.ProjectName)
 		case wizard.StepModulePath:
 			m.textInput.Placeholder = "e.g. github.com/user/my-service"
+			if m.answered[step] {
+				m.textInput.SetValue(m.state.ModulePath)
+			} else {
+				m.textInput.SetValue(wizard.SuggestModulePath(m.state.ModulePath, m.state.ProjectName))
+			}
 		case wizard.StepDescription:
 			m.textInput.Placeholder = "A short project description"
+			m.textInput.SetValue(m.state.Description)
 		case wizard.StepAuthor:
 			m.textInput.Placeholder = "Your Name <email>"
+			m.textInput.SetValue(m.state.Author)
+		case wizard.StepBinary:
+			m.textInput.Placeholder = m.state.ProjectName
+			m.textInput.SetValue(m.state.Binary)
+		case wizard.StepGitHubOwner:
+			m.textInput.Placeholder = "empty for your account, or an organisation"
+			m.textInput.SetValue(m.state.GitHubOwner)
+		case wizard.StepGitHubRepo:
+			m.textInput.Placeholder = m.state.ProjectName
+			m.textInput.SetValue(m.state.GitHubRepo)
+		case wizard.StepGitHubTopics:
+			m.textInput.Placeholder = "type a topic and press enter"
+			m.tags = slices.Clone(m.state.GitHubTopics)
+		case wizard.StepGitHubBranch:
+			m.textInput.Placeholder = "main"
+			m.textInput.SetValue(m.state.GitHubBranch)
+		case wizard.StepVars:
+			m.textInput.Placeholder = "value"
+			if missing := wizard.MissingVars(m.state); len(missing) > 0 {
+				switch m.state.RequiredVars[missing[0]].Type {
+				case config.VarTypeInt:
+					m.textInput.Placeholder = "a number"
+				case config.VarTypeBool:
+					m.textInput.Placeholder = "true or false"
+				}
+			}
+		}
+		m.refreshSuggestion()
+		return
+	}
+	if step == wizard.StepReview {
+		// Back from an edit: keep the cursor on the edited row.
+		if h := m.state.History; len(h) >= 2 && h[len(h)-2] == wizard.StepReview {
+			m.selectReviewRow(h[len(h)-1])
+		}
+		return
+	}
+	if step == wizard.StepGitHub && m.answered[step] && !m.state.GitHubEnable {
+		m.selection = 1
+		return
+	}
+	value := stepValue(m.state, step)
+	for i, c := range m.stepChoices(step) {
+		if c.Value == value {
+			m.selection = i
+		}
+	}
+}
+
+// selectReviewRow puts the review cursor on the row step answers.
+func (m *Model) selectReviewRow(step wizard.Step) {
+	for i, row := range wizard.ReviewRows(m.state) {
+		if row.Step == step {
+			m.selection = i + 1
 		}
 	}
 }
 
+// suggestion returns the suggested answer for the current step, or "".
+func (m Model) suggestion() string {
+	return m.suggest.For(m.state, m.state.CurrentStep)
+}
+
+// refreshSuggestion offers the current step's suggestion as the placeholder
+// and as a completion of what is typed.
+func (m *Model) refreshSuggestion() {
+	if !isTextInputStep(m.state.CurrentStep) {
+		return
+	}
+	if m.state.CurrentStep == wizard.StepGitHubTopics {
+		m.textInput.SetSuggestions(m.topicSuggestions())
+		return
+	}
+	s := m.suggestion()
+	if s == "" {
+		m.textInput.SetSuggestions(nil)
+		return
+	}
+	m.textInput.Placeholder = s
+	m.textInput.SetSuggestions([]string{s})
+}
+
 // isTextInputStep returns true for steps that use a text input.
 func isTextInputStep(s wizard.Step) bool {
 	switch s {
-	case wizard.StepProjectName, wizard.StepModulePath, wizard.StepDescription, wizard.StepAuthor:
+	case wizard.StepProjectName, wizard.StepModulePath, wizard.StepDescription, wizard.StepAuthor,
+		wizard.StepBinary, wizard.StepGitHubOwner, wizard.StepGitHubRepo, wizard.StepGitHubTopics,
+		wizard.StepGitHubBranch, wizard.StepVars:
 		return true
 	}
 	return false
 }
 
 // maxSelection returns the max cursor index for list steps.
-func maxSelection(s wizard.Step) int {
-	switch s {
+func (m Model) maxSelection() int {
+	switch m.state.CurrentStep {
+	case wizard.StepPreset:
+		return len(m.presets)
 	case wizard.StepProjectType:
 		return len(wizard.ProjectTypeChoices()) - 1
+	case wizard.StepRouter:
+		return len(wizard.RouterChoices()) - 1
+	case wizard.StepQueue:
+		return len(wizard.QueueChoices()) - 1
 	case wizard.StepVisibility:
 		return len(wizard.VisibilityChoices()) - 1
 	case wizard.StepCriticality:
 		return len(wizard.CriticalityChoices()) - 1
+	case wizard.StepDockerBase:
+		return len(wizard.DockerBaseChoices()) - 1
+	case wizard.StepHookManager:
+		return len(wizard.HookManagerChoices()) - 1
+	case wizard.StepTaskRunner:
+		return len(wizard.TaskRunnerChoices()) - 1
 	case wizard.StepLicense:
 		return len(wizard.LicenseChoices()) - 1
 	case wizard.StepFeatures:
-		return len(wizard.FeatureChoices()) - 1
-	case wizard.StepGitHub:
+		return len(wizard.FeatureChoicesFor(m.state.ProjectType)) - 1
+	case wizard.StepGitHub, wizard.StepGitHubPush:
 		return 1
+	case wizard.StepConfirm:
+		return len(wizard.ConfirmChoices()) - 1
+	case wizard.StepReview:
+		return len(wizard.ReviewRows(m.state))
 	}
 	return 0
 }
@@ -307,3 +677,9 @@ func (m Model) State() wizard.WizardState {
 func (m Model) Done() bool {
 	return m.done
 }
+
+// SaveOnly returns true when the wizard completed with "Save config only":
+// the configuration is to be written without generating the project.
+func (m Model) SaveOnly() bool {
+	return m.saveOnly
+}
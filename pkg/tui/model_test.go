@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/had-nu/lazy.go/pkg/wizard"
+)
+
+// press sends keys to m as the terminal would, one message per key.
+func press(m Model, keys ...tea.KeyMsg) Model {
+	for _, k := range keys {
+		next, _ := m.Update(k)
+		m = next.(Model)
+	}
+	return m
+}
+
+func runes(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+func TestHandleKey_Help(t *testing.T) {
+	f1 := tea.KeyMsg{Type: tea.KeyF1}
+	tests := []struct {
+		name      string
+		step      wizard.Step
+		keys      []tea.KeyMsg
+		wantHelp  bool
+		wantInput string
+	}{
+		{"? is typed on an empty text step", wizard.StepDescription, []tea.KeyMsg{runes("?")}, false, "?"},
+		{"? is typed after text", wizard.StepDescription, []tea.KeyMsg{runes("why"), runes("?")}, false, "why?"},
+		{"f1 opens help on a text step", wizard.StepDescription, []tea.KeyMsg{f1}, true, ""},
+		{"? opens help on a list step", wizard.StepVisibility, []tea.KeyMsg{runes("?")}, true, ""},
+		{"? closes help on a list step", wizard.StepVisibility, []tea.KeyMsg{runes("?"), runes("?")}, false, ""},
+		{"f1 opens help on a list step", wizard.StepVisibility, []tea.KeyMsg{f1}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := wizard.StateFromConfig(wizard.DefaultConfig())
+			state.CurrentStep = tt.step
+			m := press(NewFromState(state, nil), tt.keys...)
+			if m.help != tt.wantHelp {
+				t.Errorf("help = %v, want %v", m.help, tt.wantHelp)
+			}
+			if isTextInputStep(tt.step) && m.textInput.Value() != tt.wantInput {
+				t.Errorf("input = %q, want %q", m.textInput.Value(), tt.wantInput)
+			}
+		})
+	}
+}
+
+var (
+	enterKey     = tea.KeyMsg{Type: tea.KeyEnter}
+	escKey       = tea.KeyMsg{Type: tea.KeyEsc}
+	shiftTabKey  = tea.KeyMsg{Type: tea.KeyShiftTab}
+	backspaceKey = tea.KeyMsg{Type: tea.KeyBackspace}
+)
+
+// reviewModel answers the wizard's required questions and accepts every
+// default up to the review.
+func reviewModel(t *testing.T) Model {
+	t.Helper()
+	m := press(New(), runes("svc"), enterKey, runes("example.com/svc"), enterKey, enterKey, runes("Jo"), enterKey)
+	for i := 0; m.state.CurrentStep != wizard.StepReview; i++ {
+		if i == 30 || m.validErr != "" {
+			t.Fatalf("stuck on %s: %s", m.state.CurrentStep, m.validErr)
+		}
+		m = press(m, enterKey)
+	}
+	return m
+}
+
+// reviewRow returns the review cursor position of the row asked by step.
+func reviewRow(t *testing.T, m Model, step wizard.Step) int {
+	t.Helper()
+	for i, row := range wizard.ReviewRows(m.state) {
+		if row.Step == step {
+			return i + 1
+		}
+	}
+	t.Fatalf("no review row for %s", step)
+	return 0
+}
+
+func TestBack(t *testing.T) {
+	start := press(New(), runes("svc"), enterKey, runes("example.com/svc"), enterKey)
+	if start.state.CurrentStep != wizard.StepDescription {
+		t.Fatalf("step = %s, want Description", start.state.CurrentStep)
+	}
+	tests := []struct {
+		name      string
+		keys      []tea.KeyMsg
+		wantStep  wizard.Step
+		wantInput string
+	}{
+		{"shift+tab keeps the answer", []tea.KeyMsg{shiftTabKey}, wizard.StepModulePath, "example.com/svc"},
+		{"esc keeps the answer", []tea.KeyMsg{escKey}, wizard.StepModulePath, "example.com/svc"},
+		{"backspace in an empty field", []tea.KeyMsg{backspaceKey}, wizard.StepModulePath, "example.com/svc"},
+		{"backspace edits typed text", []tea.KeyMsg{runes("ab"), backspaceKey}, wizard.StepDescription, "a"},
+		{"twice", []tea.KeyMsg{escKey, escKey}, wizard.StepProjectName, "svc"},
+		{"not past the first step", []tea.KeyMsg{escKey, escKey, escKey}, wizard.StepProjectName, "svc"},
+		{"forward again", []tea.KeyMsg{escKey, enterKey}, wizard.StepDescription, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := press(start, tt.keys...)
+			if m.state.CurrentStep != tt.wantStep || m.textInput.Value() != tt.wantInput {
+				t.Errorf("at %s with %q, want %s with %q", m.state.CurrentStep, m.textInput.Value(), tt.wantStep, tt.wantInput)
+			}
+		})
+	}
+}
+
+func TestEditFromReview(t *testing.T) {
+	review := reviewModel(t)
+	tests := []struct {
+		name       string
+		step       wizard.Step
+		keys       []tea.KeyMsg
+		wantAuthor string
+		wantVis    string
+	}{
+		{"answering returns to the review", wizard.StepAuthor, []tea.KeyMsg{backspaceKey, backspaceKey, runes("Ann"), enterKey}, "Ann", "public"},
+		{"esc cancels the edit", wizard.StepAuthor, []tea.KeyMsg{runes("x"), escKey}, "Jo", "public"},
+		{"a list answer", wizard.StepVisibility, []tea.KeyMsg{{Type: tea.KeyDown}, enterKey}, "Jo", "internal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := review
+			m.selection = reviewRow(t, m, tt.step)
+			m = press(m, enterKey)
+			if m.state.CurrentStep != tt.step {
+				t.Fatalf("enter on the %s row opened %s", tt.step, m.state.CurrentStep)
+			}
+			m = press(m, tt.keys...)
+			if m.state.CurrentStep != wizard.StepReview {
+				t.Fatalf("step = %s, want the review", m.state.CurrentStep)
+			}
+			if m.selection != reviewRow(t, m, tt.step) {
+				t.Errorf("cursor on row %d, want the edited row", m.selection)
+			}
+			if m.state.Author != tt.wantAuthor || m.state.Visibility != tt.wantVis {
+				t.Errorf("author %q, visibility %q; want %q, %q", m.state.Author, m.state.Visibility, tt.wantAuthor, tt.wantVis)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package tui
+
+import (
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+	"github.com/had-nu/lazy.go/pkg/scaffold"
+	"github.com/had-nu/lazy.go/pkg/wizard"
+)
+
+// splitWidth is the terminal width from which the preview sits beside the
+// question rather than below it.
+const splitWidth = 100
+
+// preview is the file-tree pane: the tree the answers so far generate, with
+// the entries the highlighted answer would add or remove marked.
+type preview struct {
+	focused bool
+	cursor  int
+	open    string // path of the file whose contents are shown
+	content string
+}
+
+// previewMsg carries the rendered contents of a file opened in the preview.
+type previewMsg struct {
+	path    string
+	content string
+}
+
+// showPreview reports whether the current step has enough answers for a
+// meaningful tree: from the project type up to the confirmation, which
+// shows the summary instead.
+func (m Model) showPreview() bool {
+	step := m.state.CurrentStep
+	return step >= wizard.StepProjectType && step < wizard.StepConfirm
+}
+
+// pendingState returns the state as it would be with the highlighted or
+// typed answer applied, leaving m untouched.
+func (m Model) pendingState() wizard.WizardState {
+	p := m
+	p.state.Features = maps.Clone(m.state.Features)
+	p.state.Vars = maps.Clone(m.state.Vars)
+	p.toggles = maps.Clone(m.toggles)
+	if p.state.CurrentStep != wizard.StepReview {
+		_ = p.applyCurrentStep() // an invalid answer previews as unanswered
+	}
+	return p.state
+}
+
+// previewConfigs returns the configurations before and after the pending
+// answer, with security enforcement applied as it will be on generation.
+func (m Model) previewConfigs() (before, after *config.ProjectConfig) {
+	return wizard.BuildConfig(m.state), wizard.BuildConfig(m.pendingState())
+}
+
+// handlePreviewKey handles keys while the preview has focus.
+func (m Model) handlePreviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	before, after := m.previewConfigs()
+	entries := scaffold.DiffTree(before, after)
+	m.preview.cursor = min(m.preview.cursor, len(entries)-1)
+
+	switch msg.String() {
+	case "ctrl+c":
+		m.saveSession()
+		return m, tea.Quit
+	case "tab":
+		m.preview = preview{cursor: m.preview.cursor}
+	case "esc":
+		if m.preview.open != "" {
+			m.preview.open, m.preview.content = "", ""
+		} else {
+			m.preview.focused = false
+		}
+	case "up", "k":
+		if m.preview.cursor > 0 {
+			m.preview.cursor--
+		}
+	case "down", "j":
+		if m.preview.cursor < len(entries)-1 {
+			m.preview.cursor++
+		}
+	case "enter":
+		e := entries[m.preview.cursor]
+		if e.IsDir || m.preview.open == e.Path {
+			m.preview.open, m.preview.content = "", ""
+			return m, nil
+		}
+		cfg := after
+		if e.Kind == scaffold.Removed {
+			cfg = before
+		}
+		m.preview.open, m.preview.content = e.Path, "rendering…"
+		return m, renderPreviewFile(cfg, e.Path)
+	}
+	return m, nil
+}
+
+// renderPreviewFile renders a file in the background; rendering may look
+// up image digests in a registry.
+func renderPreviewFile(cfg *config.ProjectConfig, file string) tea.Cmd {
+	return func() tea.Msg {
+		content, err := scaffold.RenderFile(cfg, file)
+		if err != nil {
+			content = "✗ " + err.Error()
+		}
+		return previewMsg{path: file, content: content}
+	}
+}
+
+// withPreview lays the preview pane out beside body on wide terminals and
+// below it otherwise.
+func withPreview(m Model, body string) string {
+	if !m.showPreview() {
+		return body
+	}
+	if m.width >= splitWidth {
+		paneWidth := m.width - lipgloss.Width(body) - 2
+		return lipgloss.JoinHorizontal(lipgloss.Top, body, "  ", renderPreview(m, paneWidth))
+	}
+	return body + "\n" + renderPreview(m, m.width)
+}
+
+// renderPreview renders the preview pane at most width columns wide.
+func renderPreview(m Model, width int) string {
+	st := m.styles
+	inner := max(width-6, 20) // border and padding
+	rows := 20
+	if m.height > 0 {
+		rows = max(m.height-14, 5)
+	}
+
+	var sb strings.Builder
+	if m.preview.open != "" {
+		sb.WriteString(st.primary.Render(m.preview.open) + "  " + st.hint.Render("esc → tree") + "\n\n")
+		lines := strings.Split(strings.TrimRight(m.preview.content, "\n"), "\n")
+		for _, line := range lines[:min(len(lines), rows)] {
+			sb.WriteString(st.unselected.Render(truncate(line, inner)) + "\n")
+		}
+		if len(lines) > rows {
+			sb.WriteString(st.muted.Render("… " + strconv.Itoa(len(lines)-rows) + " more lines"))
+		}
+		return st.box.Render(strings.TrimRight(sb.String(), "\n"))
+	}
+
+	title := "Preview"
+	if m.preview.focused {
+		title += st.hint.Render("  ↑/↓ move   enter open   tab → back")
+	} else {
+		title += st.hint.Render("  tab → browse")
+	}
+	sb.WriteString(st.primary.Render(title) + "\n\n")
+
+	before, after := m.previewConfigs()
+	entries := scaffold.DiffTree(before, after)
+	cursor := min(m.preview.cursor, len(entries)-1)
+	first := 0
+	switch {
+	case m.preview.focused && cursor >= rows:
+		first = cursor - rows + 1
+	case !m.preview.focused:
+		// Bring the entries the pending answer changes into view.
+		if last := lastIndex(entries, changed); last >= rows {
+			first = min(slices.IndexFunc(entries, changed), last-rows+1)
+		}
+	}
+	if first > 0 {
+		sb.WriteString(st.muted.Render("… "+strconv.Itoa(first)+" above") + "\n")
+	}
+	for i := first; i < len(entries) && i < first+rows; i++ {
+		sb.WriteString(renderTreeEntry(st, entries[i], inner, m.preview.focused && i == cursor) + "\n")
+	}
+	if hidden := len(entries) - first - rows; hidden > 0 {
+		sb.WriteString(st.muted.Render("… "+strconv.Itoa(hidden)+" more") + "\n")
+	}
+	return st.box.Render(strings.TrimRight(sb.String(), "\n"))
+}
+
+// changed reports whether the pending answer adds or removes e.
+func changed(e scaffold.TreeEntry) bool {
+	return e.Kind != scaffold.Unchanged
+}
+
+// lastIndex returns the index of the last entry satisfying f, or -1.
+func lastIndex(entries []scaffold.TreeEntry, f func(scaffold.TreeEntry) bool) int {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if f(entries[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// renderTreeEntry renders one line of the tree, indented by depth and
+// marked when the pending answer adds or removes it.
+func renderTreeEntry(st styles, e scaffold.TreeEntry, width int, selected bool) string {
+	name := path.Base(e.Path)
+	if e.IsDir {
+		name += "/"
+	}
+	line := strings.Repeat("  ", strings.Count(e.Path, "/")) + e.Kind.Symbol() + " " + name
+	line = truncate(line, width-2)
+
+	cursor := "  "
+	if selected {
+		cursor = st.selected.Render("▶ ")
+	}
+	switch e.Kind {
+	case scaffold.Added:
+		return cursor + st.success.Render(line)
+	case scaffold.Removed:
+		return cursor + st.err.Strikethrough(true).Render(line)
+	}
+	if selected {
+		return cursor + st.selected.Render(line)
+	}
+	return cursor + st.unselected.Render(line)
+}
+
+// truncate shortens s to at most n cells, marking the cut.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n || n < 1 {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
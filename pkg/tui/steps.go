@@ -9,37 +9,42 @@ import (
 
 // View renders the current state to a string for BubbleTea.
 func (m Model) View() string {
+	st := m.styles
 	if m.done {
-		return renderDone()
+		return renderDone(st, m.saveOnly)
 	}
 
 	var sb strings.Builder
-	sb.WriteString(renderHeader(m.state))
+	sb.WriteString(renderHeader(st, m.state))
 	sb.WriteString("\n\n")
-	sb.WriteString(renderStep(m))
+	if m.help {
+		sb.WriteString(renderStep(m) + "\n" + renderHelp(m))
+	} else {
+		sb.WriteString(withPreview(m, renderStep(m)))
+	}
 	sb.WriteString("\n\n")
 	if m.validErr != "" {
-		sb.WriteString(styleError.Render("✗ "+m.validErr) + "\n\n")
+		sb.WriteString(st.err.Render("✗ "+m.validErr) + "\n\n")
 	}
-	sb.WriteString(renderHints(m.state.CurrentStep))
+	sb.WriteString(renderHints(st, m.state.CurrentStep, len(m.state.History) > 0, m.suggestion() != ""))
 	return sb.String()
 }
 
-func renderHeader(state wizard.WizardState) string {
-	title := styleHeader.Render(" lazy.go — Go Project Generator ")
-	progress := renderProgressBar(wizard.ProgressPercent(state), 40)
-	stepLabel := styleMuted.Render(fmt.Sprintf(" Step %d/%d — %s",
-		int(state.CurrentStep)+1, wizard.TotalSteps, state.CurrentStep.String()))
+func renderHeader(st styles, state wizard.WizardState) string {
+	title := st.header.Render(" lazy.go — Go Project Generator ")
+	progress := renderProgressBar(st, wizard.ProgressPercent(state), 40)
+	n, total := wizard.Position(state)
+	stepLabel := st.muted.Render(fmt.Sprintf(" Step %d/%d — %s", n, total, state.CurrentStep.String()))
 	return title + "\n" + progress + stepLabel
 }
 
-func renderProgressBar(percent, width int) string {
+func renderProgressBar(st styles, percent, width int) string {
 	filled := percent * width / 100
 	if filled > width {
 		filled = width
 	}
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
-	return styleProgress.Render(bar) + " " + styleMuted.Render(fmt.Sprintf("%d%%", percent)) + "\n"
+	return st.progress.Render(bar) + " " + st.muted.Render(fmt.Sprintf("%d%%", percent)) + "\n"
 }
 
 func renderStep(m Model) string {
@@ -49,121 +54,262 @@ func renderStep(m Model) string {
 		return renderTextInput(m)
 	case step == wizard.StepFeatures:
 		return renderFeatureToggles(m)
-	case step == wizard.StepGitHub:
-		return renderGitHubStep(m)
+	case step == wizard.StepReview:
+		return renderReview(m)
+	case step == wizard.StepConfirm:
+		return m.Summary() + "\n" + renderListSelection(m)
 	default:
 		return renderListSelection(m)
 	}
 }
 
 func renderTextInput(m Model) string {
+	st := m.styles
 	prompt := stepPrompt(m.state.CurrentStep)
-	return styleBox.Render(
-		stylePrimary.Render(prompt) + "\n\n" +
-			m.textInput.View() + "\n",
-	)
+	if m.state.CurrentStep == wizard.StepVars {
+		if missing := wizard.MissingVars(m.state); len(missing) > 0 {
+			prompt = wizard.VarPrompt(m.state, missing[0])
+		}
+	}
+	body := st.primary.Render(prompt) + "\n\n"
+	if m.state.CurrentStep == wizard.StepGitHubTopics {
+		body += renderTags(m)
+	}
+	body += m.textInput.View() + "\n"
+	// Check the answer as it is typed; enter reports the error otherwise.
+	if v := strings.TrimSpace(m.textInput.Value()); v != "" && m.validErr == "" {
+		if err := wizard.ValidateStep(m.state.CurrentStep, v); err != nil {
+			body += "\n" + st.err.Render("✗ "+err.Error()) + "\n"
+		}
+	}
+	if m.state.CurrentStep == wizard.StepGitHubTopics {
+		if s := m.topicSuggestions(); len(s) > 0 {
+			body += "\n" + st.muted.Render("Suggested: "+strings.Join(s, ", ")) + "\n"
+		}
+	}
+	return st.box.Render(body)
+}
+
+// renderTags renders the topics added so far, one tag each.
+func renderTags(m Model) string {
+	if len(m.tags) == 0 {
+		return ""
+	}
+	tags := make([]string, len(m.tags))
+	for i, t := range m.tags {
+		tags[i] = m.styles.secondary.Render("[" + t + "]")
+	}
+	return strings.Join(tags, " ") + "\n\n"
 }
 
 func renderListSelection(m Model) string {
-	choices := stepChoices(m.state.CurrentStep)
+	st := m.styles
+	choices := m.stepChoices(m.state.CurrentStep)
 	prompt := stepPrompt(m.state.CurrentStep)
 
 	var sb strings.Builder
-	sb.WriteString(stylePrimary.Render(prompt) + "\n\n")
+	sb.WriteString(st.primary.Render(prompt) + "\n\n")
 	for i, c := range choices {
 		if i == m.selection {
-			sb.WriteString(styleSelected.Render("▶  "+c.Label) + "\n")
+			sb.WriteString(st.selected.Render("▶  "+c.Label) + "\n")
 		} else {
-			sb.WriteString(styleUnselected.Render("   "+c.Label) + "\n")
+			sb.WriteString(st.unselected.Render("   "+c.Label) + "\n")
+		}
+		if c.Description != "" {
+			sb.WriteString(st.muted.Render("     "+c.Description) + "\n")
 		}
 	}
 
-	return styleBox.Render(sb.String())
+	return st.box.Render(sb.String())
 }
 
 func renderFeatureToggles(m Model) string {
-	fcs := wizard.FeatureChoices()
+	st := m.styles
+	fcs := wizard.FeatureChoicesFor(m.state.ProjectType)
 	var sb strings.Builder
-	sb.WriteString(stylePrimary.Render("Select features to enable:") + "\n\n")
+	sb.WriteString(st.primary.Render("Select features to enable:") + "\n\n")
 	for i, fc := range fcs {
 		cursor := "  "
 		if i == m.selection {
-			cursor = styleSelected.Render("▶ ")
+			cursor = st.selected.Render("▶ ")
 		}
 		toggle := "☐"
-		if m.toggles[i] {
-			toggle = styleSuccess.Render("☑")
+		if m.toggles[fc.Key] {
+			toggle = st.success.Render("☑")
 		}
 		fmt.Fprintf(&sb, "%s%s  %s\n", cursor, toggle,
-			styleUnselected.Render(fc.Label))
+			st.unselected.Render(fc.Label))
 	}
-	return styleBox.Render(sb.String())
+	return st.box.Render(sb.String())
 }
 
-func renderGitHubStep(m Model) string {
-	opts := []string{"Yes — create GitHub repository and push", "No — local project only"}
+func renderReview(m Model) string {
+	st := m.styles
 	var sb strings.Builder
-	sb.WriteString(stylePrimary.Render("Create a GitHub repository?") + "\n\n")
-	for i, opt := range opts {
-		if i == m.selection {
-			sb.WriteString(styleSelected.Render("▶  "+opt) + "\n")
+	sb.WriteString(st.primary.Render("Review your answers — select one to change it:") + "\n\n")
+	opt := "✓ Looks good — generate the project"
+	if m.selection == 0 {
+		sb.WriteString(st.selected.Render("▶  "+opt) + "\n\n")
+	} else {
+		sb.WriteString(st.unselected.Render("   "+opt) + "\n\n")
+	}
+	for i, row := range wizard.ReviewRows(m.state) {
+		line := padRight(row.Label+":", 14) + " " + row.Value
+		if i+1 == m.selection {
+			sb.WriteString(st.selected.Render("▶  "+line) + "\n")
 		} else {
-			sb.WriteString(styleUnselected.Render("   "+opt) + "\n")
+			sb.WriteString("   " + st.muted.Render(padRight(row.Label+":", 14)) + " " + st.secondary.Render(row.Value) + "\n")
 		}
 	}
-	return styleBox.Render(sb.String())
+	return st.box.Render(sb.String())
 }
 
-func renderHints(step wizard.Step) string {
+func renderHints(st styles, step wizard.Step, canGoBack, canSuggest bool) string {
 	var hints []string
-	if isTextInputStep(step) {
+	switch {
+	case step == wizard.StepGitHubTopics:
+		hints = append(hints, "enter → add topic   tab → add suggestion   backspace → remove   enter on empty → next")
+	case isTextInputStep(step) && canSuggest:
+		hints = append(hints, "tab → accept suggestion   enter → next")
+	case isTextInputStep(step):
 		hints = append(hints, "enter → next")
-	} else if step == wizard.StepFeatures {
+	case step == wizard.StepFeatures:
 		hints = append(hints, "↑/↓ move   space toggle   enter → next")
-	} else {
+	case step == wizard.StepReview:
+		hints = append(hints, "↑/↓ move   enter → edit / generate")
+	default:
 		hints = append(hints, "↑/↓ move   enter → next")
 	}
-	hints = append(hints, "ctrl+c → quit")
-	return styleHint.Render("  " + strings.Join(hints, "   "))
+	if canGoBack {
+		hints = append(hints, "shift+tab → back")
+	}
+	hints = append(hints, helpKey(step)+" → help", "ctrl+c → quit")
+	return st.hint.Render("  " + strings.Join(hints, "   "))
 }
 
-func renderDone() string {
-	return "\n" + styleSuccess.Render("  ✓ Project configuration complete!") +
-		"\n" + styleMuted.Render("  Generating your project...") + "\n\n"
+// helpKey returns the key that opens help on step: ? is typed into text
+// steps, so they use f1, which works everywhere.
+func helpKey(step wizard.Step) string {
+	if isTextInputStep(step) {
+		return "f1"
+	}
+	return "?"
+}
+
+func renderDone(st styles, saveOnly bool) string {
+	next := "  Generating your project..."
+	if saveOnly {
+		next = "  Saving lazygo.yml..."
+	}
+	return "\n" + st.success.Render("  ✓ Project configuration complete!") +
+		"\n" + st.muted.Render(next) + "\n\n"
 }
 
 // ---- Helpers ---------------------------------------------------------------
 
 type labeledChoice struct {
-	Label string
-	Value string
+	Label       string
+	Value       string
+	Description string
 }
 
-func stepChoices(step wizard.Step) []labeledChoice {
+func (m Model) stepChoices(step wizard.Step) []labeledChoice {
 	var out []labeledChoice
 	switch step {
+	case wizard.StepPreset:
+		for _, c := range wizard.PresetChoices(m.presets) {
+			out = append(out, labeledChoice{c.Label, c.Value, c.Description})
+		}
 	case wizard.StepProjectType:
 		for _, c := range wizard.ProjectTypeChoices() {
-			out = append(out, labeledChoice{c.Label, c.Value})
+			out = append(out, labeledChoice{c.Label, c.Value, c.Description})
+		}
+	case wizard.StepRouter:
+		for _, c := range wizard.RouterChoices() {
+			out = append(out, labeledChoice{c.Label, c.Value, c.Description})
+		}
+	case wizard.StepQueue:
+		for _, c := range wizard.QueueChoices() {
+			out = append(out, labeledChoice{c.Label, c.Value, c.Description})
 		}
 	case wizard.StepVisibility:
 		for _, c := range wizard.VisibilityChoices() {
-			out = append(out, labeledChoice{c.Label, c.Value})
+			out = append(out, labeledChoice{c.Label, c.Value, c.Description})
 		}
 	case wizard.StepCriticality:
 		for _, c := range wizard.CriticalityChoices() {
-			out = append(out, labeledChoice{c.Label, c.Value})
+			out = append(out, labeledChoice{c.Label, c.Value, c.Description})
+		}
+	case wizard.StepDockerBase:
+		for _, c := range wizard.DockerBaseChoices() {
+			out = append(out, labeledChoice{c.Label, c.Value, c.Description})
+		}
+	case wizard.StepHookManager:
+		for _, c := range wizard.HookManagerChoices() {
+			out = append(out, labeledChoice{c.Label, c.Value, c.Description})
+		}
+	case wizard.StepTaskRunner:
+		for _, c := range wizard.TaskRunnerChoices() {
+			out = append(out, labeledChoice{c.Label, c.Value, c.Description})
 		}
 	case wizard.StepLicense:
 		for _, c := range wizard.LicenseChoices() {
-			out = append(out, labeledChoice{c.Label, c.Value})
+			out = append(out, labeledChoice{c.Label, c.Value, c.Description})
+		}
+	case wizard.StepConfirm:
+		for _, c := range wizard.ConfirmChoices() {
+			out = append(out, labeledChoice{c.Label, c.Value, c.Description})
+		}
+	case wizard.StepGitHub:
+		out = []labeledChoice{
+			{"Yes — create a GitHub repository", "yes", "Created after generation, with GITHUB_TOKEN or the gh CLI"},
+			{"No — local project only", "no", "The project is generated on disk only"},
+		}
+	case wizard.StepGitHubPush:
+		out = []labeledChoice{
+			{"Yes — push the initial commit", "yes", "The repository starts with the generated project"},
+			{"No — create an empty repository", "no", "Push the local repository yourself when ready"},
 		}
 	}
 	return out
 }
 
+// stepValue returns the answer currently recorded in state for a list step.
+func stepValue(state wizard.WizardState, step wizard.Step) string {
+	switch step {
+	case wizard.StepPreset:
+		return state.Preset
+	case wizard.StepProjectType:
+		return state.ProjectType
+	case wizard.StepRouter:
+		return state.Router
+	case wizard.StepQueue:
+		return state.Queue
+	case wizard.StepVisibility:
+		return state.Visibility
+	case wizard.StepCriticality:
+		return state.Criticality
+	case wizard.StepDockerBase:
+		return state.DockerBase
+	case wizard.StepHookManager:
+		return state.HookManager
+	case wizard.StepTaskRunner:
+		return state.TaskRunner
+	case wizard.StepLicense:
+		return state.License
+	case wizard.StepGitHubPush:
+		if !state.GitHubPush {
+			return "no"
+		}
+		return "yes"
+	}
+	return ""
+}
+
 func stepPrompt(step wizard.Step) string {
 	switch step {
+	case wizard.StepPreset:
+		return "Start from a preset?"
 	case wizard.StepProjectName:
 		return "What is the name of your project?"
 	case wizard.StepModulePath:
@@ -174,12 +320,38 @@ func stepPrompt(step wizard.Step) string {
 		return "Your name / maintainer:"
 	case wizard.StepProjectType:
 		return "What type of project is this?"
+	case wizard.StepRouter:
+		return "Which HTTP router should the server use?"
+	case wizard.StepQueue:
+		return "Where does the worker get its jobs from?"
+	case wizard.StepBinary:
+		return "Name of the executable (empty for the project name):"
 	case wizard.StepVisibility:
 		return "Who is this project for?"
 	case wizard.StepCriticality:
 		return "What is the criticality level?"
+	case wizard.StepDockerBase:
+		return "Which runtime base image should the container use?"
+	case wizard.StepHookManager:
+		return "Which tool should manage git hooks?"
+	case wizard.StepTaskRunner:
+		return "Which task runner should drive builds?"
 	case wizard.StepLicense:
 		return "Choose a license:"
+	case wizard.StepGitHub:
+		return "Create a GitHub repository?"
+	case wizard.StepGitHubOwner:
+		return "GitHub owner (user or organisation):"
+	case wizard.StepGitHubRepo:
+		return "Repository name (empty for the project name):"
+	case wizard.StepGitHubTopics:
+		return "Repository topics:"
+	case wizard.StepGitHubBranch:
+		return "Default branch (empty for main):"
+	case wizard.StepGitHubPush:
+		return "Push the generated project to the new repository?"
+	case wizard.StepConfirm:
+		return "Generate this project?"
 	default:
 		return step.String()
 	}
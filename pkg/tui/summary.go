@@ -1,22 +1,28 @@
 package tui
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/had-nu/lazy.go/pkg/config"
+	"github.com/had-nu/lazy.go/pkg/security"
 	"github.com/had-nu/lazy.go/pkg/wizard"
 )
 
-// RenderSummary returns a human-readable summary of the collected configuration.
-func RenderSummary(state wizard.WizardState) string {
-	cfg := wizard.BuildConfig(state)
-	return renderConfigTable(cfg)
+// Summary returns a human-readable summary of the collected configuration,
+// in the wizard's theme, including what security enforcement changed from
+// the answers given and why the license was picked.
+func (m Model) Summary() string {
+	cfg := wizard.BuildConfig(m.state)
+	return renderConfigTable(m.styles, cfg, wizard.Enforcements(m.state), wizard.LicenseReason(m.state))
 }
 
-func renderConfigTable(cfg *config.ProjectConfig) string {
+func renderConfigTable(st styles, cfg *config.ProjectConfig, enforced []security.Enforcement, licenseReason string) string {
 	var sb strings.Builder
 
-	sb.WriteString(styleHeader.Render(" 📋 Project Summary ") + "\n\n")
+	sb.WriteString(st.header.Render(" 📋 Project Summary ") + "\n\n")
 
 	rows := [][]string{
 		{"Name", cfg.Name},
@@ -27,38 +33,68 @@ func renderConfigTable(cfg *config.ProjectConfig) string {
 		{"Visibility", string(cfg.Visibility)},
 		{"Criticality", string(cfg.Criticality)},
 		{"License", string(cfg.License)},
+		{"Task Runner", string(cfg.Runner())},
 	}
 
 	for _, row := range rows {
-		label := stylePrimary.Render(padRight(row[0]+":", 14))
-		value := styleSecondary.Render(row[1])
+		label := st.primary.Render(padRight(row[0]+":", 14))
+		value := st.secondary.Render(row[1])
 		sb.WriteString("  " + label + " " + value + "\n")
+		if row[0] == "License" {
+			sb.WriteString("  " + padRight("", 14) + " " + st.muted.Render(licenseReason) + "\n")
+		}
 	}
 
-	sb.WriteString("\n  " + stylePrimary.Render("Features:") + "\n")
-	appendFeature(&sb, "Tests", cfg.Features.Tests)
-	appendFeature(&sb, "Linting", cfg.Features.Linting)
-	appendFeature(&sb, "Static Analysis", cfg.Features.StaticAnalysis)
-	appendFeature(&sb, "SAST", cfg.Features.SAST)
-	appendFeature(&sb, "Docker", cfg.Features.Docker)
-	appendFeature(&sb, "GitHub Actions", cfg.Features.GitHubActions)
-	appendFeature(&sb, "Dependabot", cfg.Features.Dependabot)
+	sb.WriteString("\n  " + st.primary.Render("Features:") + "\n")
+	appendFeature(st, &sb, "Tests", cfg.Features.Tests)
+	appendFeature(st, &sb, "Linting", cfg.Features.Linting)
+	appendFeature(st, &sb, "Static Analysis", cfg.Features.StaticAnalysis)
+	appendFeature(st, &sb, "SAST", cfg.Features.SAST)
+	appendFeature(st, &sb, "Docker ("+string(cfg.DockerBase())+")", cfg.Features.Docker)
+	appendFeature(st, &sb, "GitHub Actions", cfg.Features.GitHubActions)
+	appendFeature(st, &sb, "Dependabot", cfg.Features.Dependabot)
+	appendFeature(st, &sb, "Git Hooks ("+string(cfg.HookManager())+")", cfg.Features.Hooks)
+
+	if len(enforced) > 0 {
+		sb.WriteString("\n  " + st.primary.Render("Security enforcement:") + "\n")
+		for _, e := range enforced {
+			sb.WriteString("    " + st.secondary.Render("+ "+e.String()) + "\n")
+		}
+	}
+
+	if vars := cfg.TemplateVars(); len(vars) > 0 {
+		sb.WriteString("\n  " + st.primary.Render("Variables:") + "\n")
+		for _, name := range slices.Sorted(maps.Keys(vars)) {
+			sb.WriteString("    " + st.muted.Render(padRight(name+":", 14)) + " " + st.secondary.Render(fmt.Sprint(vars[name])) + "\n")
+		}
+	}
 
 	if cfg.GitHub.Enabled {
-		sb.WriteString("\n  " + styleSuccess.Render("✓ GitHub repository will be created") + "\n")
+		sb.WriteString("\n  " + st.success.Render("✓ GitHub repository will be created") + "\n")
+	}
+
+	if diags := config.Check(cfg); len(diags) > 0 {
+		sb.WriteString("\n  " + st.primary.Render("Checks:") + "\n")
+		for _, d := range diags {
+			style := st.secondary
+			if d.Severity == config.SeverityError {
+				style = st.err
+			}
+			sb.WriteString("    " + style.Render(d.Severity.String()+": "+d.Field+": "+d.Message) + "\n")
+		}
 	}
 
-	return styleBox.Render(sb.String())
+	return st.box.Render(sb.String())
 }
 
-func appendFeature(sb *strings.Builder, name string, enabled bool) {
-	icon := styleSuccess.Render("✓")
+func appendFeature(st styles, sb *strings.Builder, name string, enabled bool) {
+	icon := st.success.Render("✓")
 	if !enabled {
-		icon = styleMuted.Render("✗")
+		icon = st.muted.Render("✗")
 	}
-	label := styleMuted.Render(name)
+	label := st.muted.Render(name)
 	if enabled {
-		label = styleUnselected.Render(name)
+		label = st.unselected.Render(name)
 	}
 	sb.WriteString("    " + icon + "  " + label + "\n")
 }
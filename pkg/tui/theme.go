@@ -0,0 +1,224 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+// Theme is a colour scheme for the wizard. A colour is "#RRGGBB" or "#RGB"
+// hex, or an ANSI colour number from 0 to 255; "" leaves the terminal's own
+// foreground, which always reads on its background.
+type Theme struct {
+	Name       string `yaml:"-"`
+	Primary    string `yaml:"primary"`     // questions, borders, the highlighted option
+	Secondary  string `yaml:"secondary"`   // answers and the progress bar
+	Muted      string `yaml:"muted"`       // labels, counts, disabled features
+	Text       string `yaml:"text"`        // options that are not highlighted
+	Hint       string `yaml:"hint"`        // the key hints under each step
+	Success    string `yaml:"success"`     // enabled features, added files
+	Error      string `yaml:"error"`       // validation errors, removed files
+	HeaderText string `yaml:"header_text"` // the title, drawn on the primary colour
+}
+
+// Built-in themes. The dark theme is the wizard's original palette.
+var (
+	DarkTheme = Theme{
+		Name:       "dark",
+		Primary:    "#7C3AED",
+		Secondary:  "#A78BFA",
+		Muted:      "#6B7280",
+		Text:       "#D1D5DB",
+		Hint:       "#4B5563",
+		Success:    "#10B981",
+		Error:      "#EF4444",
+		HeaderText: "#FFFFFF",
+	}
+
+	LightTheme = Theme{
+		Name:       "light",
+		Primary:    "#6D28D9",
+		Secondary:  "#5B21B6",
+		Muted:      "#4B5563",
+		Text:       "#1F2937",
+		Hint:       "#374151",
+		Success:    "#047857",
+		Error:      "#B91C1C",
+		HeaderText: "#FFFFFF",
+	}
+
+	// HighContrastTheme keeps the terminal's foreground for all text and
+	// marks emphasis with bold, underline and reverse video instead.
+	HighContrastTheme = Theme{
+		Name:    "high-contrast",
+		Success: "2",
+		Error:   "1",
+	}
+)
+
+// Themes returns the built-in themes.
+func Themes() []Theme {
+	return []Theme{DarkTheme, LightTheme, HighContrastTheme}
+}
+
+// ThemesDir returns the directory user themes are loaded from, themes/ in
+// config.UserDir.
+func ThemesDir() (string, error) {
+	dir, err := config.UserDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "themes"), nil
+}
+
+// LoadTheme returns the theme called name: "auto" or "" for the dark or
+// light theme matching the terminal background, a built-in theme, or
+// <name>.yml or <name>.yaml in userDir. A name containing a path separator
+// or ending in .yml or .yaml is read as a file. Colours a user theme leaves
+// out come from the theme matching the terminal background.
+func LoadTheme(name, userDir string) (Theme, error) {
+	switch name {
+	case "", "auto":
+		return autoTheme(), nil
+	}
+	for _, t := range Themes() {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+
+	file := name
+	if ext := filepath.Ext(name); !strings.ContainsRune(name, filepath.Separator) && ext != ".yml" && ext != ".yaml" {
+		if userDir == "" {
+			return Theme{}, fmt.Errorf("unknown theme %q", name)
+		}
+		file = filepath.Join(userDir, name+".yml")
+		if _, err := os.Stat(file); errors.Is(err, fs.ErrNotExist) {
+			file = filepath.Join(userDir, name+".yaml")
+		}
+	}
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Theme{}, fmt.Errorf("unknown theme %q (built-in themes are dark, light and high-contrast)", name)
+	}
+	if err != nil {
+		return Theme{}, fmt.Errorf("reading theme: %w", err)
+	}
+	t, err := ParseTheme(data, autoTheme())
+	if err != nil {
+		return Theme{}, fmt.Errorf("theme %s: %w", file, err)
+	}
+	t.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	return t, nil
+}
+
+// ParseTheme reads a YAML theme, taking the colours it leaves out from base.
+// Unknown keys and malformed colours are errors.
+func ParseTheme(data []byte, base Theme) (Theme, error) {
+	t := base
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&t); err != nil && !errors.Is(err, io.EOF) {
+		return Theme{}, err
+	}
+	colours := []struct{ key, value string }{
+		{"primary", t.Primary}, {"secondary", t.Secondary}, {"muted", t.Muted}, {"text", t.Text},
+		{"hint", t.Hint}, {"success", t.Success}, {"error", t.Error}, {"header_text", t.HeaderText},
+	}
+	for _, c := range colours {
+		if !validColour(c.value) {
+			return Theme{}, fmt.Errorf("%s: %q is not a colour; use #RRGGBB, #RGB or an ANSI number 0-255", c.key, c.value)
+		}
+	}
+	return t, nil
+}
+
+var hexColour = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validColour reports whether c is a colour a Theme accepts.
+func validColour(c string) bool {
+	if c == "" || hexColour.MatchString(c) {
+		return true
+	}
+	n, err := strconv.Atoi(c)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// autoTheme returns the dark or light theme, whichever suits the terminal
+// background. Terminals that do not answer the query count as dark.
+func autoTheme() Theme {
+	if lipgloss.HasDarkBackground() {
+		return DarkTheme
+	}
+	return LightTheme
+}
+
+// styles are the lipgloss styles the wizard renders with, built from a
+// Theme.
+type styles struct {
+	primary    lipgloss.Style
+	secondary  lipgloss.Style
+	muted      lipgloss.Style
+	success    lipgloss.Style
+	err        lipgloss.Style
+	box        lipgloss.Style
+	header     lipgloss.Style
+	selected   lipgloss.Style
+	unselected lipgloss.Style
+	progress   lipgloss.Style
+	hint       lipgloss.Style
+}
+
+// newStyles builds the wizard's styles from t.
+func newStyles(t Theme) styles {
+	fg := func(c string) lipgloss.Style {
+		s := lipgloss.NewStyle()
+		if c != "" {
+			s = s.Foreground(lipgloss.Color(c))
+		}
+		return s
+	}
+
+	st := styles{
+		primary:    fg(t.Primary).Bold(true),
+		secondary:  fg(t.Secondary),
+		muted:      fg(t.Muted),
+		success:    fg(t.Success).Bold(true),
+		err:        fg(t.Error),
+		box:        lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(1, 2),
+		header:     fg(t.HeaderText).Padding(0, 2).Bold(true),
+		selected:   fg(t.Primary).Bold(true),
+		unselected: fg(t.Text),
+		progress:   fg(t.Secondary),
+		hint:       fg(t.Hint).Italic(true),
+	}
+	if t.Primary == "" {
+		// Without a colour to draw on, set the title and highlight apart
+		// by reversing and underlining them.
+		st.header = st.header.Reverse(true)
+		st.selected = st.selected.Underline(true)
+	} else {
+		st.box = st.box.BorderForeground(lipgloss.Color(t.Primary))
+		st.header = st.header.Background(lipgloss.Color(t.Primary))
+	}
+	return st
+}
+
+// plainStyles drops colour, borders and padding from every style, for
+// output that screen readers read aloud or CI logs record.
+func plainStyles() styles {
+	plain := lipgloss.NewStyle()
+	return styles{plain, plain, plain, plain, plain, plain, plain, plain, plain, plain, plain}
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    func(Theme) bool
+		wantErr string
+	}{
+		{"empty keeps the base", "", func(th Theme) bool { return th == DarkTheme }, ""},
+		{"overrides a colour", "primary: \"#FF0000\"\n", func(th Theme) bool {
+			return th.Primary == "#FF0000" && th.Secondary == DarkTheme.Secondary
+		}, ""},
+		{"short hex", "muted: \"#abc\"\n", func(th Theme) bool { return th.Muted == "#abc" }, ""},
+		{"ANSI number", "error: \"196\"\n", func(th Theme) bool { return th.Error == "196" }, ""},
+		{"clears a colour", "text: \"\"\n", func(th Theme) bool { return th.Text == "" }, ""},
+		{"ANSI number out of range", "error: \"256\"\n", nil, `error: "256" is not a colour`},
+		{"colour name", "primary: red\n", nil, `primary: "red" is not a colour`},
+		{"bad hex", "hint: \"#12345\"\n", nil, `hint: "#12345" is not a colour`},
+		{"unknown key", "primray: \"#FF0000\"\n", nil, "field primray not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTheme([]byte(tt.data), DarkTheme)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.want(got) {
+				t.Errorf("ParseTheme = %+v", got)
+			}
+		})
+	}
+}
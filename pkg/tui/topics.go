@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/had-nu/lazy.go/pkg/wizard"
+)
+
+// The topics step is a tag input: enter or a comma adds what is typed as a
+// topic, backspace on an empty input removes the last one, tab adds the
+// next suggested topic or completes the one being typed, and enter on an
+// empty input moves on.
+
+// handleTopicKey handles the keys the topics step gives its own meaning,
+// reporting whether it did.
+func (m Model) handleTopicKey(msg tea.KeyMsg) (Model, bool) {
+	value := strings.TrimSpace(m.textInput.Value())
+	switch msg.String() {
+	case "enter", ",":
+		if value == "" {
+			return m, msg.String() == ","
+		}
+		m.addTags(value)
+		return m, true
+
+	case "backspace":
+		if value != "" || len(m.tags) == 0 {
+			return m, false
+		}
+		m.tags = m.tags[:len(m.tags)-1]
+		m.validErr = ""
+		m.refreshSuggestion()
+		return m, true
+
+	case "tab":
+		if value != "" {
+			return m, false // the input completes what was typed
+		}
+		if s := m.topicSuggestions(); len(s) > 0 {
+			m.addTags(s[0])
+			return m, true
+		}
+	}
+	return m, false
+}
+
+// addTags adds the comma-separated topics in list to the chosen ones and
+// clears the input, or reports why they are invalid.
+func (m *Model) addTags(list string) {
+	topics, err := wizard.ParseTopics(strings.Join(append(slices.Clone(m.tags), list), ","))
+	if err != nil {
+		m.validErr = err.Error()
+		return
+	}
+	m.tags = topics
+	m.validErr = ""
+	m.textInput.Reset()
+	m.refreshSuggestion()
+}
+
+// topicSuggestions returns the suggested topics not yet added.
+func (m Model) topicSuggestions() []string {
+	state := m.state
+	state.GitHubTopics = m.tags
+	return wizard.SuggestTopics(state)
+}
+
+// inputValue returns the answer typed on the current text step. On the
+// topics step it is every topic added and any still being typed, joined
+// by commas.
+func (m Model) inputValue() string {
+	if m.state.CurrentStep != wizard.StepGitHubTopics {
+		return m.textInput.Value()
+	}
+	return strings.Join(slices.DeleteFunc(append(slices.Clone(m.tags), m.textInput.Value()), func(s string) bool {
+		return strings.TrimSpace(s) == ""
+	}), ", ")
+}
+
+// setInputValue replaces the answer typed on the current text step with v.
+// On the topics step a valid list becomes the added topics.
+func (m *Model) setInputValue(v string) {
+	if m.state.CurrentStep == wizard.StepGitHubTopics {
+		m.tags = nil
+		if topics, err := wizard.ParseTopics(v); err == nil {
+			m.tags, v = topics, ""
+		}
+	}
+	m.textInput.SetValue(v)
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/had-nu/lazy.go/pkg/wizard"
+)
+
+func topicsModel(tags ...string) Model {
+	state := wizard.StateFromConfig(wizard.DefaultConfig())
+	state.ProjectName, state.ProjectType = "svc", "api"
+	state.GitHubEnable = true
+	state.GitHubTopics = tags
+	state.CurrentStep = wizard.StepGitHubTopics
+	return NewFromState(state, nil)
+}
+
+func TestAddTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		list    string
+		want    []string
+		wantErr bool
+	}{
+		{"one", nil, "go", []string{"go"}, false},
+		{"a list", []string{"go"}, "api, Cli", []string{"go", "api", "cli"}, false},
+		{"duplicates dropped", []string{"go"}, "go,api,api", []string{"go", "api"}, false},
+		{"invalid kept out", []string{"go"}, "not a topic", []string{"go"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := topicsModel(tt.tags...)
+			m.textInput.SetValue(tt.list)
+			m.addTags(tt.list)
+			if !slices.Equal(m.tags, tt.want) {
+				t.Errorf("tags = %q, want %q", m.tags, tt.want)
+			}
+			if (m.validErr != "") != tt.wantErr {
+				t.Errorf("validErr = %q", m.validErr)
+			}
+			if !tt.wantErr && m.textInput.Value() != "" {
+				t.Errorf("input not cleared: %q", m.textInput.Value())
+			}
+		})
+	}
+}
+
+func TestHandleTopicKey(t *testing.T) {
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	backspace := tea.KeyMsg{Type: tea.KeyBackspace}
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	tests := []struct {
+		name      string
+		tags      []string
+		keys      []tea.KeyMsg
+		want      []string
+		wantInput string
+		wantStep  wizard.Step
+	}{
+		{"enter adds a topic", nil, []tea.KeyMsg{runes("go"), enter}, []string{"go"}, "", wizard.StepGitHubTopics},
+		{"comma adds a topic", nil, []tea.KeyMsg{runes("go"), runes(","), runes("api")}, []string{"go"}, "api", wizard.StepGitHubTopics},
+		{"comma on empty input is ignored", []string{"go"}, []tea.KeyMsg{runes(",")}, []string{"go"}, "", wizard.StepGitHubTopics},
+		{"backspace on empty input removes the last", []string{"go", "api"}, []tea.KeyMsg{backspace}, []string{"go"}, "", wizard.StepGitHubTopics},
+		{"backspace edits typed text", []string{"go"}, []tea.KeyMsg{runes("ap"), backspace}, []string{"go"}, "a", wizard.StepGitHubTopics},
+		{"tab adds the first suggestion", nil, []tea.KeyMsg{tab}, []string{"go"}, "", wizard.StepGitHubTopics},
+		{"tab skips added suggestions", []string{"go"}, []tea.KeyMsg{tab}, []string{"go", "golang"}, "", wizard.StepGitHubTopics},
+		{"enter on empty input moves on", []string{"go"}, []tea.KeyMsg{enter}, []string{"go"}, "", wizard.StepGitHubBranch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := press(topicsModel(tt.tags...), tt.keys...)
+			if m.state.CurrentStep != tt.wantStep {
+				t.Fatalf("step = %s, want %s", m.state.CurrentStep, tt.wantStep)
+			}
+			got := m.tags
+			if tt.wantStep != wizard.StepGitHubTopics {
+				got = m.state.GitHubTopics
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("topics = %q, want %q", got, tt.want)
+			}
+			if tt.wantStep == wizard.StepGitHubTopics && m.textInput.Value() != tt.wantInput {
+				t.Errorf("input = %q, want %q", m.textInput.Value(), tt.wantInput)
+			}
+		})
+	}
+}
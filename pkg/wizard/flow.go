@@ -2,43 +2,344 @@ package wizard
 
 import (
 	"fmt"
+	"maps"
+	"path"
+	"slices"
 	"strings"
 
 	"github.com/had-nu/lazy.go/pkg/config"
+	"github.com/had-nu/lazy.go/pkg/scaffold"
 	"github.com/had-nu/lazy.go/pkg/security"
 )
 
 // NextStep returns the next wizard step based on current state.
 // This enables conditional flow (e.g. skip some steps for library projects).
 func NextStep(state WizardState) Step {
+	// Once reviewed, answering a step returns to the review, by way of
+	// the questions that follow up on it.
+	if state.Reviewing && state.CurrentStep != StepReview {
+		if next := flowStep(state); isFollowUp(next) {
+			return next
+		}
+		return varsOrReview(state)
+	}
+	return flowStep(state)
+}
+
+// flowStep returns the step that follows the current one on the first
+// pass through the wizard.
+func flowStep(state WizardState) Step {
 	switch state.CurrentStep {
+	case StepPreset:
+		return StepProjectName
 	case StepProjectName:
 		return StepModulePath
 	case StepModulePath:
+		// A preset has answered everything else.
+		if state.Preset != "" {
+			return varsOrReview(state)
+		}
 		return StepDescription
 	case StepDescription:
 		return StepAuthor
 	case StepAuthor:
 		return StepProjectType
 	case StepProjectType:
+		switch config.ProjectType(state.ProjectType) {
+		case config.ProjectTypeAPI, config.ProjectTypeMicroservice:
+			return StepRouter
+		case config.ProjectTypeWorker:
+			return StepQueue
+		case config.ProjectTypeCLI, config.ProjectTypeSecurity:
+			return StepBinary
+		}
+		return StepVisibility
+	case StepRouter:
+		if state.ProjectType == string(config.ProjectTypeMicroservice) {
+			return StepQueue
+		}
+		return StepVisibility
+	case StepQueue, StepBinary:
 		return StepVisibility
 	case StepVisibility:
 		return StepCriticality
 	case StepCriticality:
 		return StepFeatures
 	case StepFeatures:
+		if state.Features["docker"] && state.ProjectType != string(config.ProjectTypeLibrary) {
+			return StepDockerBase
+		}
+		fallthrough
+	case StepDockerBase:
+		if state.Features["hooks"] {
+			return StepHookManager
+		}
+		return StepTaskRunner
+	case StepHookManager:
+		return StepTaskRunner
+	case StepTaskRunner:
+		// Commercial projects are proprietary; there is no license to pick.
+		if state.Visibility == string(config.VisibilityPrivate) {
+			return StepGitHub
+		}
 		return StepLicense
 	case StepLicense:
 		return StepGitHub
 	case StepGitHub:
-		return StepDone
+		if state.GitHubEnable {
+			return StepGitHubOwner
+		}
+		return varsOrReview(state)
+	case StepGitHubOwner:
+		return StepGitHubRepo
+	case StepGitHubRepo:
+		return StepGitHubTopics
+	case StepGitHubTopics:
+		return StepGitHubBranch
+	case StepGitHubBranch:
+		return StepGitHubPush
+	case StepGitHubPush, StepVars:
+		return varsOrReview(state)
+	case StepReview:
+		return StepConfirm
 	default:
 		return StepDone
 	}
 }
 
+// isFollowUp reports whether step only asks about an answer to an earlier
+// step, so changing that answer from the review asks it again.
+func isFollowUp(step Step) bool {
+	switch step {
+	case StepRouter, StepQueue, StepBinary,
+		StepGitHubOwner, StepGitHubRepo, StepGitHubTopics, StepGitHubBranch, StepGitHubPush:
+		return true
+	}
+	return false
+}
+
+// Path returns the steps the wizard asks for the answers in state, from
+// the first to the confirmation. Template variables count as one step.
+func Path(state WizardState) []Step {
+	step := state.CurrentStep
+	if len(state.History) > 0 {
+		step = state.History[0]
+	}
+	var path []Step
+	for step != StepDone {
+		path = append(path, step)
+		state.CurrentStep = step
+		step = flowStep(state)
+		if step == StepVars && slices.Contains(path, StepVars) {
+			step = StepReview
+		}
+	}
+	return path
+}
+
+// Position returns the 1-based position of the current step on state's
+// Path and the length of the path.
+func Position(state WizardState) (n, total int) {
+	path := Path(state)
+	if state.CurrentStep == StepDone {
+		return len(path), len(path)
+	}
+	return max(slices.Index(path, state.CurrentStep), 0) + 1, len(path)
+}
+
+// varsOrReview asks for each required template variable still missing, one
+// StepVars at a time, before the review.
+func varsOrReview(state WizardState) Step {
+	if len(MissingVars(state)) > 0 {
+		return StepVars
+	}
+	return StepReview
+}
+
+// Forward moves state on to NextStep, recording the current step so Back
+// can return to it.
+func Forward(state WizardState) WizardState {
+	next := NextStep(state)
+	// StepVars repeats once per variable; Back skips over the repeats.
+	if next != state.CurrentStep {
+		state.History = append(slices.Clip(state.History), state.CurrentStep)
+	}
+	if next == StepReview {
+		state.Reviewing = true
+	}
+	state.CurrentStep = next
+	return state
+}
+
+// Back returns state at the step answered before the current one, keeping
+// every answer given so far. ok is false on the first step.
+func Back(state WizardState) (prev WizardState, ok bool) {
+	n := len(state.History)
+	if n == 0 {
+		return state, false
+	}
+	state.CurrentStep = state.History[n-1]
+	state.History = state.History[: n-1 : n-1]
+	// With every variable answered StepVars has nothing left to ask.
+	if state.CurrentStep == StepVars && len(MissingVars(state)) == 0 {
+		return Back(state)
+	}
+	return state, true
+}
+
+// Edit jumps from the review to step so its answer can be changed.
+// Answering it returns to the review; Back cancels the edit.
+func Edit(state WizardState, step Step) WizardState {
+	state.History = append(slices.Clip(state.History), state.CurrentStep)
+	state.CurrentStep = step
+	return state
+}
+
+// ReviewRow is one answer listed by the review step, and the step that
+// asks it.
+type ReviewRow struct {
+	Label string
+	Value string
+	Step  Step
+}
+
+// ReviewRows returns the answers in state as the review step lists them,
+// in the order they were asked. Steps the flow skipped are left out.
+func ReviewRows(state WizardState) []ReviewRow {
+	cfg := stateConfig(state)
+	rows := []ReviewRow{
+		{"Name", state.ProjectName, StepProjectName},
+		{"Module", state.ModulePath, StepModulePath},
+		{"Description", state.Description, StepDescription},
+		{"Author", state.Author, StepAuthor},
+		{"Type", choiceLabel(ProjectTypeChoices(), state.ProjectType), StepProjectType},
+	}
+	for _, step := range Path(state) {
+		switch step {
+		case StepRouter:
+			rows = append(rows, ReviewRow{"Router", choiceLabel(RouterChoices(), string(cfg.Router())), step})
+		case StepQueue:
+			rows = append(rows, ReviewRow{"Queue", choiceLabel(QueueChoices(), string(cfg.Queue())), step})
+		case StepBinary:
+			rows = append(rows, ReviewRow{"Binary", cfg.Binary(), step})
+		}
+	}
+	rows = append(rows,
+		ReviewRow{"Visibility", choiceLabel(VisibilityChoices(), state.Visibility), StepVisibility},
+		ReviewRow{"Criticality", choiceLabel(CriticalityChoices(), state.Criticality), StepCriticality},
+	)
+
+	var features []string
+	for _, fc := range FeatureChoices() {
+		if state.Features[fc.Key] {
+			features = append(features, fc.Label)
+		}
+	}
+	rows = append(rows, ReviewRow{"Features", strings.Join(features, ", "), StepFeatures})
+	if state.Features["docker"] && cfg.Type != config.ProjectTypeLibrary {
+		rows = append(rows, ReviewRow{"Docker Base", string(cfg.DockerBase()), StepDockerBase})
+	}
+	if state.Features["hooks"] {
+		rows = append(rows, ReviewRow{"Git Hooks", string(cfg.HookManager()), StepHookManager})
+	}
+
+	rows = append(rows, ReviewRow{"Task Runner", string(cfg.Runner()), StepTaskRunner})
+	license := ReviewRow{"License", choiceLabel(LicenseChoices(), state.License), StepLicense}
+	switch {
+	case cfg.Visibility == config.VisibilityPrivate:
+		// Not asked; changing the visibility is what changes it.
+		license.Value, license.Step = choiceLabel(LicenseChoices(), string(cfg.License)), StepVisibility
+	case state.License == "" || state.License == "auto":
+		license.Value = "auto (" + choiceLabel(LicenseChoices(), string(SuggestLicense(cfg))) + ")"
+	}
+	rows = append(rows, license)
+
+	if !state.GitHubEnable {
+		return append(rows, ReviewRow{"GitHub", "no", StepGitHub})
+	}
+	owner := state.GitHubOwner
+	if owner == "" {
+		owner = "(your account)"
+	}
+	push := "no"
+	if state.GitHubPush {
+		push = "yes"
+	}
+	return append(rows,
+		ReviewRow{"GitHub", "yes", StepGitHub},
+		ReviewRow{"Owner", owner, StepGitHubOwner},
+		ReviewRow{"Repository", cfg.RepoName(), StepGitHubRepo},
+		ReviewRow{"Topics", strings.Join(state.GitHubTopics, ", "), StepGitHubTopics},
+		ReviewRow{"Branch", cfg.DefaultBranch(), StepGitHubBranch},
+		ReviewRow{"Push", push, StepGitHubPush},
+	)
+}
+
+// choiceLabel returns the label of the choice with value, or value itself.
+func choiceLabel(choices []Choice, value string) string {
+	for _, c := range choices {
+		if c.Value == value {
+			return c.Label
+		}
+	}
+	return value
+}
+
+// MissingVars returns the required template variables state has no value
+// for. StepVars asks for the first one.
+func MissingVars(state WizardState) []string {
+	return stateConfig(state).MissingVars()
+}
+
+// VarPrompt returns the question StepVars asks for the variable name.
+func VarPrompt(state WizardState, name string) string {
+	if p := state.RequiredVars[name].Prompt; p != "" {
+		return p
+	}
+	return fmt.Sprintf("Value for %s:", name)
+}
+
+// SetVar validates value against the declaration of the variable name and
+// records it in state.
+func SetVar(state *WizardState, name, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fmt.Errorf("%s is required", name)
+	}
+	if _, err := config.ParseVar(state.RequiredVars[name], value); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if state.Vars == nil {
+		state.Vars = make(map[string]string)
+	}
+	state.Vars[name] = value
+	return nil
+}
+
 // BuildConfig converts a completed WizardState into a ProjectConfig.
 func BuildConfig(state WizardState) *config.ProjectConfig {
+	cfg := stateConfig(state)
+	Finalize(cfg)
+	return cfg
+}
+
+// Finalize applies the decisions the wizard makes on completion to cfg,
+// however it was assembled: security enforcement and license suggestion.
+func Finalize(cfg *config.ProjectConfig) {
+	// Single source of truth for security enforcement.
+	// EnforceSecurity is a no-op for experimental projects.
+	security.EnforceSecurity(cfg)
+
+	// Auto-suggest license when not set.
+	if cfg.License == "" || cfg.License == "auto" {
+		cfg.License = SuggestLicense(cfg)
+	}
+}
+
+// stateConfig maps state onto a ProjectConfig as answered, without
+// enforcement. Libraries get no Docker feature and commercial projects a
+// proprietary license, as the wizard does not ask for them.
+func stateConfig(state WizardState) *config.ProjectConfig {
 	cfg := &config.ProjectConfig{
 		Name:        state.ProjectName,
 		ModulePath:  state.ModulePath,
@@ -48,6 +349,7 @@ func BuildConfig(state WizardState) *config.ProjectConfig {
 		Visibility:  config.Visibility(state.Visibility),
 		Criticality: config.CriticalityLevel(state.Criticality),
 		License:     config.LicenseType(state.License),
+		TaskRunner:  config.TaskRunner(state.TaskRunner),
 		Features: config.Features{
 			Docker:         state.Features["docker"],
 			GitHubActions:  state.Features["github_actions"],
@@ -56,23 +358,201 @@ func BuildConfig(state WizardState) *config.ProjectConfig {
 			Dependabot:     state.Features["dependabot"],
 			Tests:          state.Features["tests"],
 			SAST:           state.Features["sast"],
+			Hooks:          state.Features["hooks"],
+		},
+		Docker: config.DockerConfig{
+			Base: config.DockerBase(state.DockerBase),
+		},
+		Hooks: config.HooksConfig{
+			Manager: config.HookManager(state.HookManager),
+		},
+		CLI: config.CLIConfig{
+			Binary: state.Binary,
+		},
+		API: config.APIConfig{
+			Router: config.Router(state.Router),
+		},
+		Worker: config.WorkerConfig{
+			Queue: config.QueueBackend(state.Queue),
 		},
 		GitHub: config.GitHubConfig{
-			Enabled:    state.GitHubEnable,
-			PushOnInit: state.GitHubPush,
+			Enabled:       state.GitHubEnable,
+			Owner:         state.GitHubOwner,
+			Repo:          state.GitHubRepo,
+			Topics:        state.GitHubTopics,
+			DefaultBranch: state.GitHubBranch,
+			PushOnInit:    state.GitHubPush,
 		},
+		Vars:         state.Vars,
+		RequiredVars: state.RequiredVars,
 	}
+	if cfg.Type == config.ProjectTypeLibrary {
+		cfg.Features.Docker = false
+	}
+	if cfg.Visibility == config.VisibilityPrivate {
+		cfg.License = config.LicenseProprietary
+	}
+	return cfg
+}
 
-	// Single source of truth for security enforcement.
-	// EnforceSecurity is a no-op for experimental projects.
-	security.EnforceSecurity(cfg)
+// ApplyPreset returns state with p's answers filled in, keeping the
+// current step, project name and module path.
+func ApplyPreset(state WizardState, p scaffold.Preset) (WizardState, error) {
+	cfg := stateConfig(state)
+	if err := p.Apply(cfg); err != nil {
+		return state, err
+	}
+	next := StateFromConfig(cfg)
+	next.CurrentStep = state.CurrentStep
+	next.Preset = p.Name
+	return next, nil
+}
 
-	// Auto-suggest license when not set.
-	if cfg.License == "" {
-		cfg.License = SuggestLicense(cfg)
+// DefaultConfig returns the answers the wizard starts from before any
+// defaults file is applied: no identity, the first choice of each list, and
+// the default feature set. Headless runs start from it too, so that they
+// generate what accepting every default in the wizard does.
+func DefaultConfig() *config.ProjectConfig {
+	f := DefaultFeatures()
+	return &config.ProjectConfig{
+		Visibility:  config.Visibility(VisibilityChoices()[0].Value),
+		Criticality: config.CriticalityLevel(CriticalityChoices()[0].Value),
+		TaskRunner:  config.TaskRunner(TaskRunnerChoices()[0].Value),
+		Features: config.Features{
+			Docker:         f["docker"],
+			GitHubActions:  f["github_actions"],
+			Linting:        f["linting"],
+			StaticAnalysis: f["static_analysis"],
+			Dependabot:     f["dependabot"],
+			Tests:          f["tests"],
+			SAST:           f["sast"],
+			Hooks:          f["hooks"],
+		},
+	}
+}
+
+// StateFromConfig returns a fresh wizard state pre-filled from cfg, so every
+// step opens on the configured answer. It is the inverse of BuildConfig,
+// minus security enforcement.
+func StateFromConfig(cfg *config.ProjectConfig) WizardState {
+	state := NewWizardState()
+	state.ProjectName = cfg.Name
+	state.ModulePath = cfg.ModulePath
+	state.Description = cfg.Description
+	state.Author = cfg.Author
+	state.ProjectType = string(cfg.Type)
+	state.Visibility = string(cfg.Visibility)
+	state.Criticality = string(cfg.Criticality)
+	state.DockerBase = string(cfg.Docker.Base)
+	state.HookManager = string(cfg.Hooks.Manager)
+	state.TaskRunner = string(cfg.TaskRunner)
+	state.License = string(cfg.License)
+	state.Router = string(cfg.API.Router)
+	state.Queue = string(cfg.Worker.Queue)
+	state.Binary = cfg.CLI.Binary
+	state.GitHubEnable = cfg.GitHub.Enabled
+	state.GitHubOwner = cfg.GitHub.Owner
+	state.GitHubRepo = cfg.GitHub.Repo
+	state.GitHubTopics = slices.Clone(cfg.GitHub.Topics)
+	state.GitHubBranch = cfg.GitHub.DefaultBranch
+	state.GitHubPush = cfg.GitHub.PushOnInit
+	state.Vars = maps.Clone(cfg.Vars)
+	state.RequiredVars = maps.Clone(cfg.RequiredVars)
+	state.Features = map[string]bool{
+		"docker":          cfg.Features.Docker,
+		"github_actions":  cfg.Features.GitHubActions,
+		"linting":         cfg.Features.Linting,
+		"static_analysis": cfg.Features.StaticAnalysis,
+		"dependabot":      cfg.Features.Dependabot,
+		"tests":           cfg.Features.Tests,
+		"sast":            cfg.Features.SAST,
+		"hooks":           cfg.Features.Hooks,
 	}
+	return state
+}
 
-	return cfg
+// SuggestModulePath completes a module prefix from a defaults file, such as
+// github.com/acme, with the project name. A full path is returned unchanged.
+func SuggestModulePath(prefix, name string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" || name == "" || path.Base(prefix) == name {
+		return prefix
+	}
+	return prefix + "/" + name
+}
+
+// Enforcements returns the features security enforcement turns on for
+// state's answers, with the reason for each.
+func Enforcements(state WizardState) []security.Enforcement {
+	return security.Enforcements(stateConfig(state))
+}
+
+// LicenseReason explains the license BuildConfig resolves for state: the
+// answer given, or why SuggestLicense picked its suggestion.
+func LicenseReason(state WizardState) string {
+	cfg := stateConfig(state)
+	switch {
+	case cfg.Visibility == config.VisibilityPrivate:
+		return "commercial projects keep their source closed"
+	case cfg.License != "" && cfg.License != "auto":
+		return "chosen in the wizard"
+	case cfg.Type == config.ProjectTypeLibrary && cfg.Visibility == config.VisibilityPublic:
+		return "suggested: Apache-2.0's patent grant suits public libraries"
+	case cfg.Visibility == config.VisibilityPublic:
+		return "suggested: MIT is the simplest permissive open-source license"
+	default:
+		return "suggested: internal projects are not distributed"
+	}
+}
+
+// Effect is what one answer to a question adds to the generated project
+// beyond what every other answer to it generates.
+type Effect struct {
+	Files    []string               // generated files, sorted by path
+	CIJobs   []string               // GitHub Actions jobs, in workflow order
+	Enforced []security.Enforcement // features security enforcement forces on
+}
+
+// Empty reports whether the answer adds nothing the others lack.
+func (e Effect) Empty() bool {
+	return len(e.Files) == 0 && len(e.CIJobs) == 0 && len(e.Enforced) == 0
+}
+
+// Effects compares alternatives, states that differ only in the answer to
+// one question, and returns the Effect of each in the same order. It reads
+// them off the generated tree, CI workflow and security enforcement rather
+// than describing them.
+func Effects(alternatives []WizardState) []Effect {
+	effects := make([]Effect, len(alternatives))
+	files, jobs, enforced := map[string]int{}, map[string]int{}, map[string]int{}
+	for i, state := range alternatives {
+		cfg := BuildConfig(state)
+		e := &effects[i]
+		for _, entry := range scaffold.BuildDirectoryTree(cfg) {
+			if !entry.IsDir && !slices.Contains(e.Files, entry.Path) {
+				e.Files = append(e.Files, entry.Path)
+				files[entry.Path]++
+			}
+		}
+		e.CIJobs, _ = scaffold.CIJobs(cfg) // a workflow that does not render has no jobs to list
+		for _, job := range e.CIJobs {
+			jobs[job]++
+		}
+		e.Enforced = Enforcements(state)
+		for _, en := range e.Enforced {
+			enforced[en.Feature]++
+		}
+	}
+
+	n := len(alternatives)
+	for i := range effects {
+		e := &effects[i]
+		e.Files = slices.DeleteFunc(e.Files, func(f string) bool { return files[f] == n })
+		slices.Sort(e.Files)
+		e.CIJobs = slices.DeleteFunc(e.CIJobs, func(j string) bool { return jobs[j] == n })
+		e.Enforced = slices.DeleteFunc(e.Enforced, func(en security.Enforcement) bool { return enforced[en.Feature] == n })
+	}
+	return effects
 }
 
 // SuggestLicense returns the recommended license for a project configuration.
@@ -89,55 +569,162 @@ func SuggestLicense(cfg *config.ProjectConfig) config.LicenseType {
 	}
 }
 
-// ProgressPercent returns wizard completion as 0-100.
+// ProgressPercent returns wizard completion as 0-100, measured along the
+// steps state's answers lead through rather than every step there is.
 func ProgressPercent(state WizardState) int {
-	if TotalSteps == 0 {
+	if state.CurrentStep == StepDone {
 		return 100
 	}
-	return int(state.CurrentStep) * 100 / TotalSteps
+	n, total := Position(state)
+	return (n - 1) * 100 / total
+}
+
+// PresetChoices returns display labels → values for the preset step. The
+// first choice, with an empty value, starts from scratch.
+func PresetChoices(presets []scaffold.Preset) []Choice {
+	choices := []Choice{{Label: "None — answer every question", Value: ""}}
+	for _, p := range presets {
+		label := p.Name
+		if p.Summary != "" {
+			label += " — " + p.Summary
+		}
+		choices = append(choices, Choice{Label: label, Value: p.Name})
+	}
+	return choices
 }
 
 // ProjectTypeChoices returns display labels → values for the type selection.
 func ProjectTypeChoices() []Choice {
 	return []Choice{
-		{Label: "CLI Tool", Value: string(config.ProjectTypeCLI)},
-		{Label: "REST API", Value: string(config.ProjectTypeAPI)},
-		{Label: "Microservice", Value: string(config.ProjectTypeMicroservice)},
-		{Label: "Library", Value: string(config.ProjectTypeLibrary)},
-		{Label: "Security Tool", Value: string(config.ProjectTypeSecurity)},
-		{Label: "Concurrent Worker / Service", Value: string(config.ProjectTypeWorker)},
+		{Label: "CLI Tool", Value: string(config.ProjectTypeCLI),
+			Description: "A command-line program people run in a terminal"},
+		{Label: "REST API", Value: string(config.ProjectTypeAPI),
+			Description: "An HTTP server exposing JSON endpoints"},
+		{Label: "Microservice", Value: string(config.ProjectTypeMicroservice),
+			Description: "An HTTP service with handler and service layers, one of many in a system"},
+		{Label: "Library", Value: string(config.ProjectTypeLibrary),
+			Description: "A package other modules import; no binary, no container"},
+		{Label: "Security Tool", Value: string(config.ProjectTypeSecurity),
+			Description: "A scanner-style command that reports findings"},
+		{Label: "Concurrent Worker / Service", Value: string(config.ProjectTypeWorker),
+			Description: "A long-running process that handles jobs concurrently"},
 	}
 }
 
 // VisibilityChoices returns display labels → values for visibility.
 func VisibilityChoices() []Choice {
 	return []Choice{
-		{Label: "Public (Open Source)", Value: string(config.VisibilityPublic)},
-		{Label: "Private (Internal)", Value: string(config.VisibilityInternal)},
-		{Label: "Private (Commercial)", Value: string(config.VisibilityPrivate)},
+		{Label: "Public (Open Source)", Value: string(config.VisibilityPublic),
+			Description: "Open source in a public repository"},
+		{Label: "Private (Internal)", Value: string(config.VisibilityInternal),
+			Description: "Shared inside your organisation only"},
+		{Label: "Private (Commercial)", Value: string(config.VisibilityPrivate),
+			Description: "Closed source, shipped to customers; no open-source license"},
 	}
 }
 
 // CriticalityChoices returns display labels → values for criticality.
 func CriticalityChoices() []Choice {
 	return []Choice{
-		{Label: "Experimental", Value: string(config.CriticalityExperimental)},
-		{Label: "Production", Value: string(config.CriticalityProduction)},
-		{Label: "Security Critical", Value: string(config.CriticalitySecurity)},
+		{Label: "Experimental", Value: string(config.CriticalityExperimental),
+			Description: "A prototype or spike; nothing is enforced"},
+		{Label: "Production", Value: string(config.CriticalityProduction),
+			Description: "Relied on by users or other services"},
+		{Label: "Security Critical", Value: string(config.CriticalitySecurity),
+			Description: "Handles secrets, authentication or untrusted input"},
+	}
+}
+
+// DockerBaseChoices returns display labels → values for the runtime base image.
+func DockerBaseChoices() []Choice {
+	return []Choice{
+		{Label: "Distroless static (non-root, CA certs, tzdata)", Value: string(config.DockerBaseDistroless),
+			Description: "The smallest image that still runs as non-root and verifies TLS"},
+		{Label: "Scratch (empty image)", Value: string(config.DockerBaseScratch),
+			Description: "Nothing but the binary; bring your own certificates for TLS"},
+	}
+}
+
+// HookManagerChoices returns display labels → values for the git hook manager.
+func HookManagerChoices() []Choice {
+	return []Choice{
+		{Label: "pre-commit (.pre-commit-config.yaml)", Value: string(config.HookManagerPreCommit),
+			Description: "The most widely used hook framework; needs Python"},
+		{Label: "Lefthook (lefthook.yml)", Value: string(config.HookManagerLefthook),
+			Description: "A single Go binary that runs hooks in parallel"},
+	}
+}
+
+// RouterChoices returns display labels → values for the HTTP router.
+func RouterChoices() []Choice {
+	return []Choice{
+		{Label: "net/http ServeMux (standard library)", Value: string(config.RouterStdlib),
+			Description: "No dependencies; method and wildcard patterns since Go 1.22"},
+		{Label: "chi (github.com/go-chi/chi)", Value: string(config.RouterChi),
+			Description: "Adds middleware chaining and route groups for one small dependency"},
+	}
+}
+
+// QueueChoices returns display labels → values for the worker's queue backend.
+func QueueChoices() []Choice {
+	return []Choice{
+		{Label: "None — poll on a timer", Value: string(config.QueueNone),
+			Description: "The worker runs on an interval; no broker needed"},
+		{Label: "Redis (list with BRPOP)", Value: string(config.QueueRedis),
+			Description: "Jobs are pushed to a Redis list and popped by each worker"},
+		{Label: "NATS (queue group subscription)", Value: string(config.QueueNATS),
+			Description: "Jobs are published to a NATS subject and shared by a queue group"},
+	}
+}
+
+// ConfirmChoices returns the actions offered by the confirmation step.
+func ConfirmChoices() []Choice {
+	return []Choice{
+		{Label: "Confirm — generate the project", Value: "generate"},
+		{Label: "Edit — back to the review", Value: "edit"},
+		{Label: "Save config only — write lazygo.yml without generating", Value: "save"},
+		{Label: "Cancel", Value: "cancel"},
+	}
+}
+
+// TaskRunnerChoices returns display labels → values for task runner selection.
+func TaskRunnerChoices() []Choice {
+	return []Choice{
+		{Label: "Make (Makefile)", Value: string(config.TaskRunnerMake),
+			Description: "Installed almost everywhere; tab-sensitive syntax"},
+		{Label: "Task (Taskfile.yml)", Value: string(config.TaskRunnerTask),
+			Description: "Cross-platform YAML tasks; needs the task binary"},
+		{Label: "just (justfile)", Value: string(config.TaskRunnerJust),
+			Description: "Make-like recipes without the build-system quirks; needs just"},
 	}
 }
 
 // LicenseChoices returns display labels → values for license selection.
 func LicenseChoices() []Choice {
 	return []Choice{
-		{Label: fmt.Sprintf("Auto-suggest (recommended: %s)", "based on context"), Value: "auto"},
-		{Label: "MIT", Value: string(config.LicenseMIT)},
-		{Label: "Apache-2.0", Value: string(config.LicenseApache2)},
-		{Label: "GPL-3.0", Value: string(config.LicenseGPL3)},
-		{Label: "Proprietary (no license)", Value: string(config.LicenseProprietary)},
+		{Label: fmt.Sprintf("Auto-suggest (recommended: %s)", "based on context"), Value: "auto",
+			Description: "Picked from the visibility and project type"},
+		{Label: "MIT", Value: string(config.LicenseMIT),
+			Description: "Permissive and short: keep the notice, do anything else"},
+		{Label: "Apache-2.0", Value: string(config.LicenseApache2),
+			Description: "Permissive, with an explicit patent grant"},
+		{Label: "GPL-3.0", Value: string(config.LicenseGPL3),
+			Description: "Copyleft: distributed changes stay open under the GPL"},
+		{Label: "Proprietary (no license)", Value: string(config.LicenseProprietary),
+			Description: "All rights reserved"},
 	}
 }
 
+// FeatureChoicesFor returns the features offered for a project type:
+// libraries build no image, so Docker is left out.
+func FeatureChoicesFor(projectType string) []ToggleChoice {
+	fcs := FeatureChoices()
+	if projectType == string(config.ProjectTypeLibrary) {
+		fcs = slices.DeleteFunc(fcs, func(fc ToggleChoice) bool { return fc.Key == "docker" })
+	}
+	return fcs
+}
+
 // FeatureChoices returns all optional features with labels.
 func FeatureChoices() []ToggleChoice {
 	return []ToggleChoice{
@@ -148,13 +735,15 @@ func FeatureChoices() []ToggleChoice {
 		{Key: "docker", Label: "Docker"},
 		{Key: "dependabot", Label: "Dependabot"},
 		{Key: "sast", Label: "SAST / govulncheck"},
+		{Key: "hooks", Label: "Git hooks (format, lint, secret scan)"},
 	}
 }
 
 // Choice is a labeled selection option.
 type Choice struct {
-	Label string
-	Value string
+	Label       string
+	Value       string
+	Description string // one line shown beneath the label
 }
 
 // ToggleChoice is a feature toggle option.
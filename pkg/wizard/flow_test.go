@@ -1,9 +1,12 @@
 package wizard
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/had-nu/lazy.go/pkg/config"
+	"github.com/had-nu/lazy.go/pkg/scaffold"
 )
 
 func TestBuildConfig_EnforcesSecurityForProduction(t *testing.T) {
@@ -30,3 +33,372 @@ func TestBuildConfig_EnforcesSecurityForProduction(t *testing.T) {
 		t.Error("production project with GH Actions must have Dependabot")
 	}
 }
+
+func TestBuildConfig_SecurityCriticalEnablesHooks(t *testing.T) {
+	state := WizardState{
+		ProjectName: "scanner",
+		ModulePath:  "github.com/x/scanner",
+		ProjectType: string(config.ProjectTypeSecurity),
+		Criticality: string(config.CriticalitySecurity),
+		Features:    map[string]bool{},
+	}
+
+	if cfg := BuildConfig(state); !cfg.Features.Hooks {
+		t.Error("security-critical project must have git hooks")
+	}
+}
+
+func TestStateFromConfig_RoundTrip(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Author = "Platform Team"
+	cfg.Visibility = config.VisibilityInternal
+	cfg.Features.SAST = true
+
+	state := StateFromConfig(cfg)
+	if state.CurrentStep != StepPreset {
+		t.Errorf("CurrentStep = %v, want the first step", state.CurrentStep)
+	}
+	if state.Author != "Platform Team" || state.Visibility != string(config.VisibilityInternal) {
+		t.Errorf("identity not pre-filled: %+v", state)
+	}
+	if !state.Features["sast"] || !state.Features["tests"] {
+		t.Errorf("features not pre-filled: %v", state.Features)
+	}
+}
+
+func TestDefaultConfig_StartsFromFirstChoices(t *testing.T) {
+	cfg := DefaultConfig()
+	if got, want := string(cfg.Visibility), VisibilityChoices()[0].Value; got != want {
+		t.Errorf("Visibility = %q, want the wizard's default %q", got, want)
+	}
+	if got, want := string(cfg.Criticality), CriticalityChoices()[0].Value; got != want {
+		t.Errorf("Criticality = %q, want the wizard's default %q", got, want)
+	}
+	if got, want := string(cfg.TaskRunner), TaskRunnerChoices()[0].Value; got != want {
+		t.Errorf("TaskRunner = %q, want the wizard's default %q", got, want)
+	}
+}
+
+func TestSuggestModulePath(t *testing.T) {
+	cases := []struct{ prefix, name, want string }{
+		{"github.com/acme", "svc", "github.com/acme/svc"},
+		{"github.com/acme/", "svc", "github.com/acme/svc"},
+		{"github.com/acme/svc", "svc", "github.com/acme/svc"},
+		{"", "svc", ""},
+	}
+	for _, c := range cases {
+		if got := SuggestModulePath(c.prefix, c.name); got != c.want {
+			t.Errorf("SuggestModulePath(%q, %q) = %q, want %q", c.prefix, c.name, got, c.want)
+		}
+	}
+}
+
+func TestApplyPreset_ShortensWizard(t *testing.T) {
+	p, err := scaffold.FindPreset("", "internal-api-prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := StateFromConfig(DefaultConfig())
+	state.ProjectName = "billing"
+
+	state, err = ApplyPreset(state, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.CurrentStep != StepPreset || state.ProjectName != "billing" {
+		t.Errorf("ApplyPreset moved the wizard or lost the name: %+v", state)
+	}
+	if state.ProjectType != string(config.ProjectTypeAPI) || !state.Features["docker"] {
+		t.Errorf("preset answers not applied: %+v", state)
+	}
+
+	state.CurrentStep = StepModulePath
+	if next := NextStep(state); next != StepReview {
+		t.Errorf("NextStep after module path with a preset = %v, want Review", next)
+	}
+	state.Preset = ""
+	if next := NextStep(state); next != StepDescription {
+		t.Errorf("NextStep after module path without a preset = %v, want Description", next)
+	}
+}
+
+func TestBuildConfig_AutoLicense(t *testing.T) {
+	state := WizardState{
+		ProjectType: string(config.ProjectTypeLibrary),
+		Visibility:  string(config.VisibilityPublic),
+		License:     "auto",
+		Features:    map[string]bool{},
+	}
+	if got := BuildConfig(state).License; got != config.LicenseApache2 {
+		t.Errorf("License = %q, want the suggestion for a public library", got)
+	}
+}
+
+func TestNextStep_AsksMissingVars(t *testing.T) {
+	state := StateFromConfig(DefaultConfig())
+	state.RequiredVars = map[string]config.VarSpec{
+		"team":     {Prompt: "Owning team?"},
+		"replicas": {Type: config.VarTypeInt},
+		"region":   {Default: "eu-west-1"},
+	}
+	state.CurrentStep = StepGitHub
+
+	var asked []string
+	for next := NextStep(state); next == StepVars; next = NextStep(state) {
+		state.CurrentStep = next
+		name := MissingVars(state)[0]
+		asked = append(asked, name)
+		if name == "replicas" {
+			if err := SetVar(&state, name, "three"); err == nil {
+				t.Error("SetVar accepted a non-integer for an int variable")
+			}
+		}
+		value := map[string]string{"team": "payments", "replicas": "3"}[name]
+		if err := SetVar(&state, name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := []string{"replicas", "team"}; !slices.Equal(asked, want) {
+		t.Errorf("asked for %v, want %v", asked, want)
+	}
+	if got := VarPrompt(state, "team"); got != "Owning team?" {
+		t.Errorf("VarPrompt = %q", got)
+	}
+	if vars := BuildConfig(state).TemplateVars(); vars["replicas"] != 3 || vars["region"] != "eu-west-1" {
+		t.Errorf("TemplateVars = %v", vars)
+	}
+}
+
+func TestForwardBack_RestoresAnswers(t *testing.T) {
+	state := StateFromConfig(DefaultConfig())
+	state.CurrentStep = StepProjectName
+	if _, ok := Back(state); ok {
+		t.Error("Back succeeded on the first step")
+	}
+
+	state.ProjectName = "svc"
+	state = Forward(state)
+	state.ModulePath = "github.com/x/svc"
+	state = Forward(state)
+	if state.CurrentStep != StepDescription {
+		t.Fatalf("CurrentStep = %v, want Description", state.CurrentStep)
+	}
+
+	prev, ok := Back(state)
+	if !ok || prev.CurrentStep != StepModulePath || prev.ModulePath != "github.com/x/svc" {
+		t.Errorf("Back = %v (ok %v), module %q", prev.CurrentStep, ok, prev.ModulePath)
+	}
+	prev, _ = Back(prev)
+	if prev.CurrentStep != StepProjectName || len(prev.History) != 0 {
+		t.Errorf("Back = %v with history %v", prev.CurrentStep, prev.History)
+	}
+	if state.CurrentStep != StepDescription || len(state.History) != 2 {
+		t.Errorf("Back modified the original state: %v %v", state.CurrentStep, state.History)
+	}
+}
+
+func TestEdit_ReturnsToReview(t *testing.T) {
+	state := StateFromConfig(DefaultConfig())
+	state.ProjectType = string(config.ProjectTypeAPI)
+	state.CurrentStep = StepGitHub
+	state = Forward(state)
+	if state.CurrentStep != StepReview || !state.Reviewing {
+		t.Fatalf("after GitHub: %v, reviewing %v", state.CurrentStep, state.Reviewing)
+	}
+
+	state = Edit(state, StepProjectType)
+	state.ProjectType = string(config.ProjectTypeLibrary)
+	if prev, _ := Back(state); prev.CurrentStep != StepReview {
+		t.Errorf("Back from an edit = %v, want Review", prev.CurrentStep)
+	}
+	state = Forward(state)
+	if state.CurrentStep != StepReview {
+		t.Errorf("Forward from an edit = %v, want Review", state.CurrentStep)
+	}
+	if next := Forward(state); next.CurrentStep != StepConfirm {
+		t.Errorf("Forward from the review = %v, want Confirm", next.CurrentStep)
+	}
+}
+
+func TestReviewRows_FollowFlow(t *testing.T) {
+	state := StateFromConfig(DefaultConfig())
+	state.ProjectType = string(config.ProjectTypeLibrary)
+	state.Visibility = string(config.VisibilityPublic)
+	state.License = "auto"
+	state.Features["docker"] = true
+
+	rows := map[string]ReviewRow{}
+	for _, r := range ReviewRows(state) {
+		rows[r.Label] = r
+	}
+	if _, ok := rows["Docker Base"]; ok {
+		t.Error("libraries are never asked for a Docker base image")
+	}
+	if r := rows["License"]; r.Value != "auto (Apache-2.0)" || r.Step != StepLicense {
+		t.Errorf("License row = %+v", r)
+	}
+	if r := rows["Type"]; r.Value != "Library" {
+		t.Errorf("Type row = %+v, want the choice label", r)
+	}
+}
+
+func TestPath_FollowsTypeAndVisibility(t *testing.T) {
+	cases := []struct {
+		name      string
+		typ       config.ProjectType
+		vis       config.Visibility
+		github    bool
+		want, not []Step
+	}{
+		{"api", config.ProjectTypeAPI, config.VisibilityPublic, false,
+			[]Step{StepRouter, StepLicense}, []Step{StepQueue, StepBinary, StepGitHubOwner, StepGitHubBranch}},
+		{"microservice", config.ProjectTypeMicroservice, config.VisibilityInternal, false,
+			[]Step{StepRouter, StepQueue}, []Step{StepBinary}},
+		{"worker", config.ProjectTypeWorker, config.VisibilityPublic, false,
+			[]Step{StepQueue}, []Step{StepRouter, StepBinary}},
+		{"private cli", config.ProjectTypeCLI, config.VisibilityPrivate, true,
+			[]Step{StepBinary, StepGitHubOwner, StepGitHubRepo, StepGitHubTopics, StepGitHubBranch, StepGitHubPush},
+			[]Step{StepLicense, StepRouter}},
+		{"library", config.ProjectTypeLibrary, config.VisibilityPublic, false,
+			[]Step{StepLicense}, []Step{StepRouter, StepQueue, StepBinary}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := StateFromConfig(DefaultConfig())
+			state.ProjectType = string(c.typ)
+			state.Visibility = string(c.vis)
+			state.GitHubEnable = c.github
+
+			path := Path(state)
+			for _, s := range c.want {
+				if !slices.Contains(path, s) {
+					t.Errorf("path %v lacks %v", path, s)
+				}
+			}
+			for _, s := range c.not {
+				if slices.Contains(path, s) {
+					t.Errorf("path %v asks %v", path, s)
+				}
+			}
+			if end := path[len(path)-2:]; !slices.Equal(end, []Step{StepReview, StepConfirm}) {
+				t.Errorf("path ends at %v, want Review, Confirm", end)
+			}
+		})
+	}
+}
+
+func TestProgressPercent_MeasuresPath(t *testing.T) {
+	state := StateFromConfig(DefaultConfig())
+	state.ProjectType = string(config.ProjectTypeLibrary)
+	state.CurrentStep = StepProjectName
+	for state.CurrentStep != StepConfirm {
+		state = Forward(state)
+	}
+
+	n, total := Position(state)
+	if n != total || total >= TotalSteps {
+		t.Errorf("Position at the confirmation = %d/%d, want the end of a path shorter than %d", n, total, TotalSteps)
+	}
+	if got := ProgressPercent(state); got != (total-1)*100/total {
+		t.Errorf("ProgressPercent = %d", got)
+	}
+	state.CurrentStep = StepDone
+	if got := ProgressPercent(state); got != 100 {
+		t.Errorf("ProgressPercent when done = %d, want 100", got)
+	}
+}
+
+func TestEdit_AsksFollowUps(t *testing.T) {
+	state := StateFromConfig(DefaultConfig())
+	state.ProjectType = string(config.ProjectTypeLibrary)
+	state.CurrentStep = StepReview
+	state.Reviewing = true
+
+	state = Edit(state, StepProjectType)
+	state.ProjectType = string(config.ProjectTypeAPI)
+	state = Forward(state)
+	if state.CurrentStep != StepRouter {
+		t.Fatalf("Forward after changing the type = %v, want Router", state.CurrentStep)
+	}
+	state.Router = string(config.RouterChi)
+	if state = Forward(state); state.CurrentStep != StepReview {
+		t.Errorf("Forward after the router = %v, want Review", state.CurrentStep)
+	}
+}
+
+func TestBuildConfig_SkippedAnswers(t *testing.T) {
+	state := StateFromConfig(DefaultConfig())
+	state.ProjectType = string(config.ProjectTypeLibrary)
+	state.Visibility = string(config.VisibilityPrivate)
+	state.License = string(config.LicenseMIT)
+	state.Features["docker"] = true
+
+	cfg := BuildConfig(state)
+	if cfg.Features.Docker {
+		t.Error("library built with Docker")
+	}
+	if cfg.License != config.LicenseProprietary {
+		t.Errorf("License = %q, want proprietary for a commercial project", cfg.License)
+	}
+	if slices.ContainsFunc(FeatureChoicesFor(state.ProjectType), func(fc ToggleChoice) bool { return fc.Key == "docker" }) {
+		t.Error("libraries are offered Docker")
+	}
+}
+
+func TestEnforcementsAndLicenseReason(t *testing.T) {
+	state := StateFromConfig(DefaultConfig())
+	state.ProjectType = string(config.ProjectTypeLibrary)
+	state.Visibility = string(config.VisibilityPublic)
+	state.Criticality = string(config.CriticalityProduction)
+	state.License = "auto"
+	state.Features = map[string]bool{"tests": true, "github_actions": true}
+
+	var got []string
+	for _, e := range Enforcements(state) {
+		got = append(got, e.Feature)
+	}
+	if want := []string{"static_analysis", "dependabot", "sast"}; !slices.Equal(got, want) {
+		t.Errorf("Enforcements = %v, want %v", got, want)
+	}
+	if e := Enforcements(state)[2]; e.String() != "SAST forced on because criticality=production" {
+		t.Errorf("Enforcement = %q", e)
+	}
+	if reason := LicenseReason(state); !strings.Contains(reason, "Apache-2.0") {
+		t.Errorf("LicenseReason = %q", reason)
+	}
+	state.Criticality = string(config.CriticalityExperimental)
+	if got := Enforcements(state); len(got) != 0 {
+		t.Errorf("Enforcements for an experimental project = %v", got)
+	}
+}
+
+func TestEffects_Criticality(t *testing.T) {
+	state := StateFromConfig(DefaultConfig())
+	state.ProjectName, state.ModulePath = "lib", "github.com/x/lib"
+	state.ProjectType = string(config.ProjectTypeLibrary)
+	state.Features = map[string]bool{"tests": true, "github_actions": true}
+
+	var alternatives []WizardState
+	for _, c := range CriticalityChoices() {
+		state.Criticality = c.Value
+		alternatives = append(alternatives, state)
+	}
+	effects := Effects(alternatives)
+
+	if !effects[0].Empty() {
+		t.Errorf("experimental adds %+v, want nothing", effects[0])
+	}
+	production := effects[1]
+	if !slices.Equal(production.CIJobs, []string{"Lint", "Security Scan"}) {
+		t.Errorf("production CI jobs = %v", production.CIJobs)
+	}
+	if !slices.Contains(production.Files, "SECURITY.md") || slices.Contains(production.Files, ".pre-commit-config.yaml") {
+		t.Errorf("production files = %v", production.Files)
+	}
+	if len(production.Enforced) != 3 {
+		t.Errorf("production enforces %v", production.Enforced)
+	}
+	if security := effects[2]; !slices.Contains(security.Files, ".pre-commit-config.yaml") {
+		t.Errorf("security-critical files = %v, want git hooks", security.Files)
+	}
+}
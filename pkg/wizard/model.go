@@ -1,25 +1,44 @@
 package wizard
 
+import "github.com/had-nu/lazy.go/pkg/config"
+
 // Step represents a single step in the interactive wizard.
 type Step int
 
 const (
-	StepProjectName Step = iota
+	StepPreset Step = iota
+	StepProjectName
 	StepModulePath
 	StepDescription
 	StepAuthor
 	StepProjectType
+	StepRouter
+	StepQueue
+	StepBinary
 	StepVisibility
 	StepCriticality
 	StepFeatures
+	StepDockerBase
+	StepHookManager
+	StepTaskRunner
 	StepLicense
 	StepGitHub
+	StepGitHubOwner
+	StepGitHubRepo
+	StepGitHubTopics
+	StepGitHubBranch
+	StepGitHubPush
+	StepVars
+	StepReview
+	StepConfirm
 	StepDone
 )
 
 // String returns a human-readable label for the step.
 func (s Step) String() string {
 	switch s {
+	case StepPreset:
+		return "Preset"
 	case StepProjectName:
 		return "Project Name"
 	case StepModulePath:
@@ -30,16 +49,44 @@ func (s Step) String() string {
 		return "Author"
 	case StepProjectType:
 		return "Project Type"
+	case StepRouter:
+		return "HTTP Router"
+	case StepQueue:
+		return "Queue Backend"
+	case StepBinary:
+		return "Binary Name"
 	case StepVisibility:
 		return "Visibility"
 	case StepCriticality:
 		return "Criticality"
 	case StepFeatures:
 		return "Features"
+	case StepDockerBase:
+		return "Docker Base Image"
+	case StepHookManager:
+		return "Git Hooks"
+	case StepTaskRunner:
+		return "Task Runner"
 	case StepLicense:
 		return "License"
 	case StepGitHub:
 		return "GitHub Integration"
+	case StepGitHubOwner:
+		return "GitHub Owner"
+	case StepGitHubRepo:
+		return "GitHub Repository"
+	case StepGitHubTopics:
+		return "GitHub Topics"
+	case StepGitHubBranch:
+		return "Default Branch"
+	case StepGitHubPush:
+		return "GitHub Push"
+	case StepVars:
+		return "Template Variables"
+	case StepReview:
+		return "Review"
+	case StepConfirm:
+		return "Confirm"
 	case StepDone:
 		return "Done"
 	default:
@@ -47,29 +94,45 @@ func (s Step) String() string {
 	}
 }
 
-// TotalSteps is the total number of wizard steps (excluding StepDone).
+// TotalSteps is the total number of wizard steps (excluding StepDone). A
+// single run asks only those on its Path.
 const TotalSteps = int(StepDone)
 
 // WizardState holds all answers collected by the wizard so far.
 type WizardState struct {
 	CurrentStep  Step
+	Preset       string
 	ProjectName  string
 	ModulePath   string
 	Description  string
 	Author       string
 	ProjectType  string
+	Router       string // API and microservice projects
+	Queue        string // worker and microservice projects
+	Binary       string // CLI and security tool projects
 	Visibility   string
 	Criticality  string
 	Features     map[string]bool
+	DockerBase   string
+	HookManager  string
+	TaskRunner   string
 	License      string
 	GitHubEnable bool
+	GitHubOwner  string
+	GitHubRepo   string // empty for the project name
+	GitHubTopics []string
+	GitHubBranch string // empty for main
 	GitHubPush   bool
+	Vars         map[string]string         // template variables
+	RequiredVars map[string]config.VarSpec // declared by a preset or defaults file
+	History      []Step                    // steps answered before CurrentStep, oldest first
+	Reviewing    bool                      // the review step has been reached
 }
 
 // NewWizardState initialises a fresh wizard state at the first step.
 func NewWizardState() WizardState {
 	return WizardState{
-		CurrentStep: StepProjectName,
+		CurrentStep: StepPreset,
 		Features:    make(map[string]bool),
 	}
 }
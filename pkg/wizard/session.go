@@ -0,0 +1,100 @@
+package wizard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// SessionTTL is how long an unfinished session can be resumed.
+const SessionTTL = 7 * 24 * time.Hour
+
+// sessionVersion is the format of Session. Bump it when a change to
+// Session or WizardState would restore an old session wrongly.
+const sessionVersion = 1
+
+// ErrNoSession is returned by LoadSession when there is no session to
+// resume: none was saved, it expired, or an older lazy.go saved it.
+var ErrNoSession = errors.New("no unfinished wizard session")
+
+// Session is an unfinished wizard run, saved after every step so that it
+// can be resumed after the terminal closes or the wizard is interrupted.
+type Session struct {
+	Version   int             // sessionVersion of the lazy.go that saved it
+	Steps     []string        // names of its steps, in order
+	Saved     time.Time       // when it was last saved
+	State     WizardState     // answers so far and the step to resume on
+	Answered  []Step          // steps answered in the interrupted run
+	Toggles   map[string]bool // feature checkboxes, including unconfirmed ones
+	Selection int             // cursor on the current step
+	Input     string          // text typed on the current step
+}
+
+// SessionPath returns the session file in the user cache directory.
+func SessionPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating the cache directory: %w", err)
+	}
+	return filepath.Join(dir, "lazygo", "session.json"), nil
+}
+
+// SaveSession writes s to path, stamping it with the current time.
+func SaveSession(path string, s Session) error {
+	s.Version, s.Steps, s.Saved = sessionVersion, stepNames(), time.Now()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding session: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating session directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing session: %w", err)
+	}
+	return nil
+}
+
+// LoadSession reads the session at path. A session saved more than
+// SessionTTL ago, or by a lazy.go with another session format or other
+// steps, is removed and ErrNoSession returned, as for a missing file.
+func LoadSession(path string) (Session, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Session{}, ErrNoSession
+	}
+	if err != nil {
+		return Session{}, fmt.Errorf("reading session: %w", err)
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil || s.Version != sessionVersion ||
+		!slices.Equal(s.Steps, stepNames()) || time.Since(s.Saved) > SessionTTL {
+		_ = DiscardSession(path)
+		return Session{}, ErrNoSession
+	}
+	return s, nil
+}
+
+// stepNames returns the names of every step in order. Steps are saved by
+// number, so a session only resumes correctly with the same steps.
+func stepNames() []string {
+	names := make([]string, TotalSteps)
+	for i := range names {
+		names[i] = Step(i).String()
+	}
+	return names
+}
+
+// DiscardSession removes the session at path. A missing file is not an
+// error.
+func DiscardSession(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing session: %w", err)
+	}
+	return nil
+}
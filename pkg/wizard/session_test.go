@@ -0,0 +1,99 @@
+package wizard_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/had-nu/lazy.go/pkg/wizard"
+)
+
+func TestSession_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazygo", "session.json")
+	state := wizard.NewWizardState()
+	state.ProjectName = "billing"
+	state.CurrentStep = wizard.StepFeatures
+	want := wizard.Session{
+		State:     state,
+		Answered:  []wizard.Step{wizard.StepProjectName},
+		Toggles:   map[string]bool{"docker": true},
+		Selection: 2,
+	}
+	if err := wizard.SaveSession(path, want); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("session file mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+
+	got, err := wizard.LoadSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.State.ProjectName != "billing" || got.State.CurrentStep != wizard.StepFeatures {
+		t.Errorf("State = %q at %s", got.State.ProjectName, got.State.CurrentStep)
+	}
+	if !got.Toggles["docker"] || got.Selection != 2 || len(got.Answered) != 1 {
+		t.Errorf("Toggles %v, Selection %d, Answered %v", got.Toggles, got.Selection, got.Answered)
+	}
+
+	if err := wizard.DiscardSession(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wizard.LoadSession(path); !errors.Is(err, wizard.ErrNoSession) {
+		t.Errorf("LoadSession after DiscardSession = %v, want ErrNoSession", err)
+	}
+	if err := wizard.DiscardSession(path); err != nil {
+		t.Errorf("DiscardSession of a missing file = %v", err)
+	}
+}
+
+func TestLoadSession_DiscardsStale(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(map[string]any)
+	}{
+		{"expired", func(s map[string]any) { s["Saved"] = time.Now().Add(-wizard.SessionTTL - time.Hour) }},
+		{"older format", func(s map[string]any) { s["Version"] = 0 }},
+		{"step count only", func(s map[string]any) { s["Steps"] = wizard.TotalSteps }},
+		{"renamed step", func(s map[string]any) { s["Steps"].([]any)[1] = "Name" }},
+		{"reordered steps", func(s map[string]any) {
+			steps := s["Steps"].([]any)
+			steps[1], steps[2] = steps[2], steps[1]
+		}},
+		{"removed step", func(s map[string]any) { s["Steps"] = s["Steps"].([]any)[1:] }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "session.json")
+			if err := wizard.SaveSession(path, wizard.Session{State: wizard.NewWizardState()}); err != nil {
+				t.Fatal(err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var raw map[string]any
+			if err := json.Unmarshal(data, &raw); err != nil {
+				t.Fatal(err)
+			}
+			tt.mutate(raw)
+			if data, err = json.Marshal(raw); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, data, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := wizard.LoadSession(path); !errors.Is(err, wizard.ErrNoSession) {
+				t.Errorf("LoadSession = %v, want ErrNoSession", err)
+			}
+			if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("stale session was not removed: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package wizard
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/had-nu/lazy.go/pkg/config"
+)
+
+// Suggestions are answers guessed from the environment the wizard runs
+// in. The text steps offer them as a placeholder that tab accepts; none is
+// filled in unasked.
+type Suggestions struct {
+	ProjectName string // the current directory, when it is empty
+	Description string // the first line of prose in an existing README
+	Author      string // git config user.name and user.email
+	GitHubLogin string // the authenticated GitHub user, for the module path
+}
+
+// For returns the suggestion for a text step given the answers in state,
+// or "" when there is none.
+func (s Suggestions) For(state WizardState, step Step) string {
+	var suggestion string
+	switch step {
+	case StepProjectName:
+		suggestion = s.ProjectName
+	case StepModulePath:
+		if s.GitHubLogin != "" && state.ProjectName != "" {
+			suggestion = "github.com/" + s.GitHubLogin + "/" + state.ProjectName
+		}
+	case StepDescription:
+		suggestion = s.Description
+	case StepAuthor:
+		suggestion = s.Author
+	case StepBinary, StepGitHubRepo:
+		suggestion = state.ProjectName
+	}
+	if suggestion == "" || ValidateStep(step, suggestion) != nil {
+		return ""
+	}
+	return suggestion
+}
+
+// projectTopics are the topics suggested for each project type.
+var projectTopics = map[config.ProjectType][]string{
+	config.ProjectTypeCLI:          {"cli"},
+	config.ProjectTypeAPI:          {"rest-api", "http-server"},
+	config.ProjectTypeMicroservice: {"microservice", "http-server"},
+	config.ProjectTypeLibrary:      {"library"},
+	config.ProjectTypeSecurity:     {"security", "security-tools"},
+	config.ProjectTypeWorker:       {"worker", "background-jobs"},
+}
+
+// SuggestTopics returns repository topics describing the project in state,
+// from its type, router, queue and features, leaving out those already
+// chosen.
+func SuggestTopics(state WizardState) []string {
+	cfg := BuildConfig(state)
+	topics := append([]string{"go", "golang"}, projectTopics[cfg.Type]...)
+	if (cfg.Type == config.ProjectTypeAPI || cfg.Type == config.ProjectTypeMicroservice) && cfg.Router() == config.RouterChi {
+		topics = append(topics, "chi")
+	}
+	if cfg.Type == config.ProjectTypeWorker || cfg.Type == config.ProjectTypeMicroservice {
+		switch cfg.Queue() {
+		case config.QueueRedis:
+			topics = append(topics, "redis")
+		case config.QueueNATS:
+			topics = append(topics, "nats")
+		}
+	}
+	if cfg.Features.Docker {
+		topics = append(topics, "docker")
+	}
+	if cfg.Criticality == config.CriticalitySecurity {
+		topics = append(topics, "security")
+	}
+
+	var out []string
+	for _, t := range topics {
+		if !slices.Contains(out, t) && !slices.Contains(state.GitHubTopics, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// DetectSuggestions gathers suggestions from dir and the local git
+// configuration. Anything it cannot find is left empty. The GitHub login
+// needs the network and is looked up separately.
+func DetectSuggestions(dir string) Suggestions {
+	var s Suggestions
+	if entries, err := os.ReadDir(dir); err == nil && isEmptyDir(entries) {
+		if abs, err := filepath.Abs(dir); err == nil {
+			s.ProjectName = SanitizeProjectName(filepath.Base(abs))
+		}
+	}
+	for _, name := range []string{"README.md", "README", "README.txt"} {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+			s.Description = ReadmeDescription(data)
+			break
+		}
+	}
+	name, email := gitConfig(dir, "user.name"), gitConfig(dir, "user.email")
+	switch {
+	case name != "" && email != "":
+		s.Author = name + " <" + email + ">"
+	case name != "":
+		s.Author = name
+	}
+	return s
+}
+
+// isEmptyDir reports whether a directory listing has nothing but hidden
+// entries such as .git.
+func isEmptyDir(entries []os.DirEntry) bool {
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), ".") {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadmeDescription returns the first line of prose in a README, skipping
+// headings, badges and HTML, or "" if there is none.
+func ReadmeDescription(readme []byte) string {
+	sc := bufio.NewScanner(bytes.NewReader(readme))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "",
+			strings.HasPrefix(line, "#"),
+			strings.HasPrefix(line, "!["),
+			strings.HasPrefix(line, "[!["),
+			strings.HasPrefix(line, "<"),
+			strings.Trim(line, "=-") == "":
+			continue
+		}
+		return line
+	}
+	return ""
+}
+
+// gitConfig returns a git configuration value as seen from dir, or "".
+func gitConfig(dir, key string) string {
+	cmd := exec.Command("git", "config", "--get", key) //nolint:gosec
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,76 @@
+package wizard_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/had-nu/lazy.go/pkg/wizard"
+)
+
+func TestReadmeDescription(t *testing.T) {
+	readme := "# billing\n\n[![CI](https://x/badge.svg)](https://x)\n\nInvoices and payment runs for Acme.\n\nMore text.\n"
+	if got := wizard.ReadmeDescription([]byte(readme)); got != "Invoices and payment runs for Acme." {
+		t.Errorf("ReadmeDescription = %q", got)
+	}
+	if got := wizard.ReadmeDescription([]byte("Title\n=====\n")); got != "Title" {
+		t.Errorf("ReadmeDescription of a setext heading = %q", got)
+	}
+}
+
+func TestDetectSuggestions(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "My Tool")
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if s := wizard.DetectSuggestions(dir); s.ProjectName != "my-tool" {
+		t.Errorf("ProjectName in an empty directory = %q, want my-tool", s.ProjectName)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# tool\nDoes things.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s := wizard.DetectSuggestions(dir)
+	if s.ProjectName != "" {
+		t.Errorf("ProjectName in a non-empty directory = %q", s.ProjectName)
+	}
+	if s.Description != "Does things." {
+		t.Errorf("Description = %q", s.Description)
+	}
+}
+
+func TestSuggestions_For(t *testing.T) {
+	s := wizard.Suggestions{GitHubLogin: "octocat", Description: strings.Repeat("x", 300)}
+	state := wizard.NewWizardState()
+	if got := s.For(state, wizard.StepModulePath); got != "" {
+		t.Errorf("module path suggested before the name: %q", got)
+	}
+	state.ProjectName = "svc"
+	if got := s.For(state, wizard.StepModulePath); got != "github.com/octocat/svc" {
+		t.Errorf("module path suggestion = %q", got)
+	}
+	if got := s.For(state, wizard.StepDescription); got != "" {
+		t.Errorf("an invalid description was suggested: %q", got)
+	}
+}
+
+func TestSuggestTopics(t *testing.T) {
+	state := wizard.StateFromConfig(wizard.DefaultConfig())
+	state.ProjectType = "microservice"
+	state.Router = "chi"
+	state.Queue = "nats"
+	state.Criticality = "experimental"
+	state.Features["docker"] = true
+	state.GitHubTopics = []string{"golang"}
+
+	got := strings.Join(wizard.SuggestTopics(state), " ")
+	if want := "go microservice http-server chi nats docker"; got != want {
+		t.Errorf("SuggestTopics = %q, want %q", got, want)
+	}
+
+	state.ProjectType = "library"
+	if got := strings.Join(wizard.SuggestTopics(state), " "); got != "go library" {
+		t.Errorf("SuggestTopics for a library = %q, want go library", got)
+	}
+}
@@ -3,13 +3,19 @@ package wizard
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"unicode"
+
+	"github.com/had-nu/lazy.go/pkg/config"
 )
 
 var (
 	validProjectName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9\-_]{0,63}$`)
 	validModulePath  = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9\-._/~]*$`)
+	validBinary      = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9\-._]{0,63}$`)
+	validOwner       = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9]|-[a-zA-Z0-9]){0,38}$`)
+	validTopic       = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
 )
 
 // ValidateProjectName checks that name is safe for directory and module use.
@@ -73,6 +79,93 @@ func ValidateAuthor(author string) error {
 	return nil
 }
 
+// ValidateBinary checks the name of the built executable. Empty means the
+// project name.
+func ValidateBinary(name string) error {
+	name = strings.TrimSpace(name)
+	if name != "" && !validBinary.MatchString(name) {
+		return fmt.Errorf("binary name must start with a letter or digit and contain only letters, digits, dots, hyphens, or underscores (max 64 chars)")
+	}
+	return nil
+}
+
+// ValidateGitHubOwner checks a GitHub user or organisation name. Empty
+// means the authenticated user.
+func ValidateGitHubOwner(owner string) error {
+	owner = strings.TrimSpace(owner)
+	if owner != "" && !validOwner.MatchString(owner) {
+		return fmt.Errorf("GitHub owner must contain only letters, digits, or single hyphens, and cannot start or end with a hyphen (max 39 chars)")
+	}
+	return nil
+}
+
+// ValidateRepoName checks a GitHub repository name. Empty means the
+// project name.
+func ValidateRepoName(name string) error {
+	name = strings.TrimSpace(name)
+	if name != "" && !config.ValidRepoName(name) {
+		return fmt.Errorf("repository name must contain only letters, digits, dots, hyphens, or underscores (max 100 chars)")
+	}
+	return nil
+}
+
+// ValidateBranch checks a git branch name. Empty means main.
+func ValidateBranch(name string) error {
+	name = strings.TrimSpace(name)
+	if name != "" && !config.ValidBranchName(name) {
+		return fmt.Errorf("branch name must start with a letter or digit and contain only letters, digits, dots, hyphens, underscores, or slashes, with no '..'")
+	}
+	return nil
+}
+
+// ParseTopics splits a comma-separated topic list, lowercasing each topic
+// and dropping empty and repeated entries, and checks it against GitHub's
+// rules.
+func ParseTopics(list string) ([]string, error) {
+	var topics []string
+	for _, t := range strings.Split(list, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || slices.Contains(topics, t) {
+			continue
+		}
+		if !validTopic.MatchString(t) {
+			return nil, fmt.Errorf("topic %q must start with a letter or digit and contain only lowercase letters, digits, or hyphens (max 50 chars)", t)
+		}
+		topics = append(topics, t)
+	}
+	if len(topics) > 20 {
+		return nil, fmt.Errorf("GitHub allows at most 20 topics")
+	}
+	return topics, nil
+}
+
+// ValidateStep checks value as the answer to a text step, with the
+// validator that step applies. Steps without one accept any value.
+func ValidateStep(step Step, value string) error {
+	switch step {
+	case StepProjectName:
+		return ValidateProjectName(value)
+	case StepModulePath:
+		return ValidateModulePath(value)
+	case StepDescription:
+		return ValidateDescription(value)
+	case StepAuthor:
+		return ValidateAuthor(value)
+	case StepBinary:
+		return ValidateBinary(value)
+	case StepGitHubOwner:
+		return ValidateGitHubOwner(value)
+	case StepGitHubRepo:
+		return ValidateRepoName(value)
+	case StepGitHubTopics:
+		_, err := ParseTopics(value)
+		return err
+	case StepGitHubBranch:
+		return ValidateBranch(value)
+	}
+	return nil
+}
+
 // SanitizeProjectName returns a safe version of the project name.
 func SanitizeProjectName(name string) string {
 	name = strings.TrimSpace(name)
@@ -1,6 +1,7 @@
 package wizard_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/had-nu/lazy.go/pkg/wizard"
@@ -73,6 +74,58 @@ func TestValidateAuthor_Valid(t *testing.T) {
 	}
 }
 
+func TestValidateGitHubOwner(t *testing.T) {
+	for _, c := range []string{"", "octocat", "acme-corp", "a1"} {
+		if err := wizard.ValidateGitHubOwner(c); err != nil {
+			t.Errorf("expected %q to be valid: %v", c, err)
+		}
+	}
+	for _, c := range []string{"-acme", "acme-", "ac--me", "acme/corp", "a_b"} {
+		if err := wizard.ValidateGitHubOwner(c); err == nil {
+			t.Errorf("expected %q to be invalid", c)
+		}
+	}
+}
+
+func TestValidateRepoName(t *testing.T) {
+	for _, c := range []string{"", "svc", "svc-go", "my_app.v2"} {
+		if err := wizard.ValidateRepoName(c); err != nil {
+			t.Errorf("expected %q to be valid: %v", c, err)
+		}
+	}
+	for _, c := range []string{"acme/svc", "my app", "..", strings.Repeat("a", 101)} {
+		if err := wizard.ValidateRepoName(c); err == nil {
+			t.Errorf("expected %q to be invalid", c)
+		}
+	}
+}
+
+func TestValidateBranch(t *testing.T) {
+	for _, c := range []string{"", "main", "trunk", "release/v1.0"} {
+		if err := wizard.ValidateBranch(c); err != nil {
+			t.Errorf("expected %q to be valid: %v", c, err)
+		}
+	}
+	for _, c := range []string{"-main", "a..b", "feature/", "topic.lock", "has space", "a//b"} {
+		if err := wizard.ValidateBranch(c); err == nil {
+			t.Errorf("expected %q to be invalid", c)
+		}
+	}
+}
+
+func TestParseTopics(t *testing.T) {
+	topics, err := wizard.ParseTopics(" Go, cli,, security-tools, go ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(topics, " "); got != "go cli security-tools" {
+		t.Errorf("ParseTopics = %q", got)
+	}
+	if _, err := wizard.ParseTopics("go lang"); err == nil {
+		t.Error("expected a topic with a space to be invalid")
+	}
+}
+
 func TestSanitizeProjectName(t *testing.T) {
 	cases := []struct {
 		in, out string